@@ -0,0 +1,452 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+
+	"github.com/jobspy/scrapers/internal/config"
+	"github.com/jobspy/scrapers/internal/redis"
+)
+
+// Doctor exit codes distinguish "the config itself doesn't parse/validate"
+// from "the config is fine but something it points at is unreachable" -
+// useful for a Kubernetes init container to tell a CrashLoopBackOff-worthy
+// misconfiguration apart from a transient dependency outage it should just
+// retry past.
+const (
+	doctorExitOK             = 0
+	doctorExitConfigInvalid  = 1
+	doctorExitDependencyDown = 2
+)
+
+// doctorStatus is the per-check outcome doctorCheck reports.
+type doctorStatus string
+
+const (
+	doctorOK   doctorStatus = "ok"
+	doctorWarn doctorStatus = "warn"
+	doctorFail doctorStatus = "fail"
+)
+
+// doctorCheck is one entity `doctor` probed: a Redis connection, a site's
+// DNS name, a proxy, etc. LatencyMS is omitted from JSON when the check
+// didn't measure one.
+type doctorCheck struct {
+	Name      string       `json:"name"`
+	Status    doctorStatus `json:"status"`
+	Detail    string       `json:"detail"`
+	LatencyMS int64        `json:"latency_ms,omitempty"`
+}
+
+// doctorConfigField is one entry in the effective-configuration summary:
+// the value doctor resolved plus where it came from, so an operator can
+// tell a surprising value apart from a deliberately-set one.
+type doctorConfigField struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"` // "default", "env", or "file"
+}
+
+// doctorReport is the full `doctor` output, serialized as-is for
+// --format=json or rendered as a table for --format=text.
+type doctorReport struct {
+	Checks       []doctorCheck       `json:"checks"`
+	ConfigFields []doctorConfigField `json:"config"`
+}
+
+func (r *doctorReport) add(name string, status doctorStatus, detail string, latency time.Duration) {
+	c := doctorCheck{Name: name, Status: status, Detail: detail}
+	if latency > 0 {
+		c.LatencyMS = latency.Milliseconds()
+	}
+	r.Checks = append(r.Checks, c)
+}
+
+func (r *doctorReport) worstStatus() doctorStatus {
+	worst := doctorOK
+	for _, c := range r.Checks {
+		if c.Status == doctorFail {
+			return doctorFail
+		}
+		if c.Status == doctorWarn {
+			worst = doctorWarn
+		}
+	}
+	return worst
+}
+
+// runDoctor implements `doctor [--format=text|json]`: it loads
+// configuration the same way main() would, then - like ffuf's `debug
+// doctor` - enumerates every entity a worker depends on and reports a
+// per-entity status, continuing past the first failure rather than
+// aborting, so a single bad proxy doesn't hide that Redis is also
+// unreachable. Exit code distinguishes an invalid config
+// (doctorExitConfigInvalid, nothing further was checked) from a valid
+// config with an unreachable dependency (doctorExitDependencyDown).
+func runDoctor(args []string) int {
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	format := fs.String("format", "text", "output format: text or json")
+	if err := fs.Parse(args); err != nil {
+		return doctorExitConfigInvalid
+	}
+	if *format != "text" && *format != "json" {
+		fmt.Fprintf(os.Stderr, "doctor: unknown --format %q (want text or json)\n", *format)
+		return doctorExitConfigInvalid
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		if *format == "json" {
+			out, _ := json.MarshalIndent(map[string]string{"error": err.Error()}, "", "  ")
+			fmt.Println(string(out))
+		} else {
+			fmt.Fprintf(os.Stderr, "config invalid: %v\n", err)
+		}
+		return doctorExitConfigInvalid
+	}
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+
+	report := &doctorReport{}
+	report.add("config", doctorOK, "loaded and passed validation", 0)
+
+	checkRedis(report, cfg, logger)
+	checkSiteDNS(report, cfg)
+	checkSiteTLS(report, cfg)
+	checkProxies(report, cfg)
+	checkJobSpyAPI(report, cfg)
+	report.ConfigFields = effectiveConfigFields(cfg)
+
+	if *format == "json" {
+		out, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "doctor: failed to marshal report: %v\n", err)
+			return doctorExitDependencyDown
+		}
+		fmt.Println(string(out))
+	} else {
+		printDoctorReportText(report)
+	}
+
+	if report.worstStatus() == doctorFail {
+		return doctorExitDependencyDown
+	}
+	return doctorExitOK
+}
+
+func checkRedis(report *doctorReport, cfg *config.Config, logger *logrus.Logger) {
+	start := time.Now()
+	client, err := redis.NewClient(&redis.Config{
+		URL:          cfg.RedisURL,
+		Password:     cfg.RedisPassword,
+		DB:           cfg.RedisDB,
+		DialTimeout:  5 * time.Second,
+		ReadTimeout:  5 * time.Second,
+		WriteTimeout: 5 * time.Second,
+	}, logger)
+	if err != nil {
+		report.add("redis", doctorFail, err.Error(), time.Since(start))
+		return
+	}
+	defer client.Close()
+
+	if err := client.Health(); err != nil {
+		report.add("redis", doctorFail, err.Error(), time.Since(start))
+		return
+	}
+	report.add("redis", doctorOK, cfg.RedisURL, time.Since(start))
+}
+
+// siteBaseURLs returns the BaseURL configured for each built-in site, so
+// the DNS/TLS checks below don't have to know about IndeedConfig,
+// LinkedInConfig, and GlassdoorConfig individually.
+func siteBaseURLs(cfg *config.Config) map[string]string {
+	return map[string]string{
+		"indeed":    cfg.IndeedConfig.BaseURL,
+		"linkedin":  cfg.LinkedInConfig.BaseURL,
+		"glassdoor": cfg.GlassdoorConfig.BaseURL,
+	}
+}
+
+func checkSiteDNS(report *doctorReport, cfg *config.Config) {
+	resolver := net.Resolver{}
+	for site, base := range siteBaseURLs(cfg) {
+		name := fmt.Sprintf("dns:%s", site)
+		host, err := hostOf(base)
+		if err != nil {
+			report.add(name, doctorFail, err.Error(), 0)
+			continue
+		}
+
+		start := time.Now()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		addrs, err := resolver.LookupHost(ctx, host)
+		cancel()
+		if err != nil {
+			report.add(name, doctorFail, fmt.Sprintf("%s: %v", host, err), time.Since(start))
+			continue
+		}
+		report.add(name, doctorOK, fmt.Sprintf("%s -> %s", host, strings.Join(addrs, ", ")), time.Since(start))
+	}
+}
+
+// doctorCertExpiryWarnWindow is how close to expiry a site's leaf
+// certificate can get before checkSiteTLS downgrades "ok" to "warn"
+// instead of waiting for outright expiry to report "fail".
+const doctorCertExpiryWarnWindow = 14 * 24 * time.Hour
+
+func checkSiteTLS(report *doctorReport, cfg *config.Config) {
+	for site, base := range siteBaseURLs(cfg) {
+		name := fmt.Sprintf("tls:%s", site)
+		host, err := hostOf(base)
+		if err != nil {
+			report.add(name, doctorFail, err.Error(), 0)
+			continue
+		}
+
+		start := time.Now()
+		dialer := &net.Dialer{Timeout: 5 * time.Second}
+		conn, err := tls.DialWithDialer(dialer, "tcp", net.JoinHostPort(host, "443"), &tls.Config{ServerName: host})
+		latency := time.Since(start)
+		if err != nil {
+			report.add(name, doctorFail, err.Error(), latency)
+			continue
+		}
+		certs := conn.ConnectionState().PeerCertificates
+		conn.Close()
+		if len(certs) == 0 {
+			report.add(name, doctorFail, "no peer certificates presented", latency)
+			continue
+		}
+
+		expiry := certs[0].NotAfter
+		remaining := time.Until(expiry)
+		detail := fmt.Sprintf("leaf cert expires %s (in %s)", expiry.Format(time.RFC3339), remaining.Round(time.Hour))
+		switch {
+		case remaining <= 0:
+			report.add(name, doctorFail, detail, latency)
+		case remaining <= doctorCertExpiryWarnWindow:
+			report.add(name, doctorWarn, detail, latency)
+		default:
+			report.add(name, doctorOK, detail, latency)
+		}
+	}
+}
+
+// checkProxies CONNECT-probes every static proxy in cfg.ProxyPool against a
+// canary host, the same way an HTTP client would establish a tunnel
+// through it for an HTTPS request. This only exercises ProxyPool, the
+// static "always known upfront" source - proxies discovered later via a
+// dynamic scraper/proxy.Provider (file/dns/http) are probed by
+// scraper/proxy.Pool itself at runtime, not by doctor.
+func checkProxies(report *doctorReport, cfg *config.Config) {
+	canary := "www.google.com:443"
+	for _, proxyAddr := range cfg.ProxyPool {
+		name := fmt.Sprintf("proxy:%s", proxyAddr)
+		addr, err := proxyDialAddr(proxyAddr)
+		if err != nil {
+			report.add(name, doctorFail, err.Error(), 0)
+			continue
+		}
+
+		start := time.Now()
+		conn, err := net.DialTimeout("tcp", addr, 5*time.Second)
+		if err != nil {
+			report.add(name, doctorFail, err.Error(), time.Since(start))
+			continue
+		}
+
+		conn.SetDeadline(time.Now().Add(5 * time.Second))
+		if _, err := fmt.Fprintf(conn, "CONNECT %s HTTP/1.1\r\nHost: %s\r\n\r\n", canary, canary); err != nil {
+			conn.Close()
+			report.add(name, doctorFail, fmt.Sprintf("CONNECT write failed: %v", err), time.Since(start))
+			continue
+		}
+		status, err := readHTTPStatusLine(conn)
+		conn.Close()
+		latency := time.Since(start)
+		if err != nil {
+			report.add(name, doctorFail, fmt.Sprintf("CONNECT read failed: %v", err), latency)
+			continue
+		}
+		if !strings.Contains(status, "200") {
+			report.add(name, doctorFail, fmt.Sprintf("CONNECT %s -> %s", canary, status), latency)
+			continue
+		}
+		report.add(name, doctorOK, fmt.Sprintf("CONNECT %s -> %s", canary, status), latency)
+	}
+}
+
+func checkJobSpyAPI(report *doctorReport, cfg *config.Config) {
+	base := cfg.JobSpyAPIURL
+	if base == "" {
+		base = "http://localhost:8000"
+	}
+	healthURL := strings.TrimRight(base, "/") + "/health"
+
+	start := time.Now()
+	httpClient := &http.Client{Timeout: 5 * time.Second}
+	resp, err := httpClient.Get(healthURL)
+	latency := time.Since(start)
+	if err != nil {
+		report.add("jobspy_api", doctorFail, err.Error(), latency)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		report.add("jobspy_api", doctorOK, fmt.Sprintf("%s -> %s", healthURL, resp.Status), latency)
+		return
+	}
+	report.add("jobspy_api", doctorFail, fmt.Sprintf("%s -> %s", healthURL, resp.Status), latency)
+}
+
+// doctorConfigKeys lists the mapstructure keys effectiveConfigFields
+// reports, in output order. Kept to a curated subset - the fields that
+// most often explain a misbehaving worker - rather than the full Config
+// struct, which would bury the signal in rarely-tuned performance knobs.
+var doctorConfigKeys = []string{
+	"redis_url",
+	"scraper_type",
+	"scraper_backend",
+	"region",
+	"concurrency",
+	"proxy_pool",
+	"proxy_provider_type",
+	"rate_limit_rpm",
+	"respect_retry_after",
+	"output_formats",
+	"output_dir",
+	"jobspy_api_url",
+	"metrics_enabled",
+	"metrics_addr",
+	"process_cpu_threshold_percent",
+	"peer_staleness_timeout",
+	"log_level",
+}
+
+// effectiveConfigFields reports, for each key in doctorConfigKeys, the
+// value config.LoadConfig resolved and whether it came from the config
+// file, an environment variable, or a DefaultConfig default.
+// viper.IsSet is false unless a key was set via the config file or an
+// env var (this repo never calls viper.SetDefault), so distinguishing
+// those two from "default" only needs viper.InConfig on top of that.
+func effectiveConfigFields(cfg *config.Config) []doctorConfigField {
+	values := map[string]interface{}{
+		"redis_url":                     cfg.RedisURL,
+		"scraper_type":                  cfg.ScraperType,
+		"scraper_backend":               cfg.ScraperBackend,
+		"region":                        cfg.Region,
+		"concurrency":                   cfg.Concurrency,
+		"proxy_pool":                    cfg.ProxyPool,
+		"proxy_provider_type":           cfg.ProxyProviderType,
+		"rate_limit_rpm":                cfg.RateLimitRPM,
+		"respect_retry_after":           cfg.RespectRetryAfter,
+		"output_formats":                cfg.OutputFormats,
+		"output_dir":                    cfg.OutputDir,
+		"jobspy_api_url":                cfg.JobSpyAPIURL,
+		"metrics_enabled":               cfg.MetricsEnabled,
+		"metrics_addr":                  cfg.MetricsAddr,
+		"process_cpu_threshold_percent": cfg.ProcessCPUThresholdPercent,
+		"peer_staleness_timeout":        cfg.PeerStalenessTimeout,
+		"log_level":                     cfg.LogLevel,
+	}
+
+	fields := make([]doctorConfigField, 0, len(doctorConfigKeys))
+	for _, key := range doctorConfigKeys {
+		fields = append(fields, doctorConfigField{
+			Key:    key,
+			Value:  fmt.Sprintf("%v", values[key]),
+			Source: configFieldSource(key),
+		})
+	}
+	return fields
+}
+
+func configFieldSource(key string) string {
+	if !viper.IsSet(key) {
+		return "default"
+	}
+	if viper.InConfig(key) {
+		return "file"
+	}
+	return "env"
+}
+
+func printDoctorReportText(report *doctorReport) {
+	fmt.Println("CHECKS")
+	for _, c := range report.Checks {
+		latency := ""
+		if c.LatencyMS > 0 {
+			latency = fmt.Sprintf(" (%dms)", c.LatencyMS)
+		}
+		fmt.Printf("  [%s] %-16s %s%s\n", strings.ToUpper(string(c.Status)), c.Name, c.Detail, latency)
+	}
+
+	fmt.Println("\nEFFECTIVE CONFIG")
+	for _, f := range report.ConfigFields {
+		fmt.Printf("  %-22s = %-30s (%s)\n", f.Key, f.Value, f.Source)
+	}
+}
+
+// hostOf returns the hostname portion of a site BaseURL, tolerating a
+// missing scheme (url.Parse otherwise treats "example.com" as a path, not
+// a host).
+func hostOf(rawURL string) (string, error) {
+	if rawURL == "" {
+		return "", fmt.Errorf("base_url is empty")
+	}
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid base_url %q: %w", rawURL, err)
+	}
+	if u.Hostname() == "" {
+		return "", fmt.Errorf("base_url %q has no host", rawURL)
+	}
+	return u.Hostname(), nil
+}
+
+// proxyDialAddr returns the host:port dial address for a ProxyPool entry,
+// which may be a bare "host:port" or a "scheme://host:port" URL.
+func proxyDialAddr(proxyAddr string) (string, error) {
+	if !strings.Contains(proxyAddr, "://") {
+		return proxyAddr, nil
+	}
+	u, err := url.Parse(proxyAddr)
+	if err != nil {
+		return "", fmt.Errorf("invalid proxy address %q: %w", proxyAddr, err)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("proxy address %q has no host", proxyAddr)
+	}
+	return u.Host, nil
+}
+
+func readHTTPStatusLine(conn net.Conn) (string, error) {
+	buf := make([]byte, 512)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return "", err
+	}
+	line := string(buf[:n])
+	if idx := strings.Index(line, "\r\n"); idx != -1 {
+		line = line[:idx]
+	}
+	return line, nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jobspy/scrapers/internal/scraper/rules"
+)
+
+// runDryRunRules implements `--dry-run-rules <rules-dir> <fixture-file>`: it
+// loads every rule in rules-dir and runs it against fixture-file's text
+// content, printing what each rule would extract, without starting the
+// worker loop or touching Redis.
+//
+// There is no HTML-fetching/parsing pipeline anywhere in this binary - the
+// only scraper backend calls a JSON API - so there is no true HTML page to
+// validate a CSS/XPath selector against. fixture-file is instead expected
+// to hold the kind of text a rule's selectors actually run against in
+// production: a saved protocol.JobData.Description. CSS/XPath selectors are
+// reported the same way Rule.Extract reports them at runtime: declared but
+// not executed.
+func runDryRunRules(args []string) int {
+	if len(args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: --dry-run-rules <rules-dir> <fixture-file>")
+		return 2
+	}
+	rulesDir, fixturePath := args[0], args[1]
+
+	ruleSet, loadErrs := rules.LoadRules(rulesDir)
+	for _, err := range loadErrs {
+		fmt.Fprintf(os.Stderr, "load error: %v\n", err)
+	}
+	if len(ruleSet.Rules) == 0 {
+		fmt.Fprintf(os.Stderr, "no rules loaded from %s\n", rulesDir)
+		return 1
+	}
+
+	fixture, err := os.ReadFile(fixturePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to read fixture %s: %v\n", fixturePath, err)
+		return 1
+	}
+
+	exitCode := 0
+	for _, rule := range ruleSet.Rules {
+		fields, extractErrs := rule.Extract(string(fixture))
+		fmt.Printf("rule %s (%s/%s@%s):\n", rule.Name, rule.Site, rule.PageType, rule.Version)
+		for name, value := range fields {
+			fmt.Printf("  %s = %q\n", name, value)
+		}
+		for _, err := range extractErrs {
+			fmt.Printf("  ! %v\n", err)
+			exitCode = 1
+		}
+	}
+
+	return exitCode
+}
@@ -12,6 +12,12 @@ import (
 
 // Config holds all configuration for the scraper workers
 type Config struct {
+	// ConfigFilePath is the config file viper resolved, if any. Empty when
+	// running purely from environment variables and defaults. Only set
+	// when reading was successful, so a Watcher can be attached to it for
+	// hot-reload.
+	ConfigFilePath string `mapstructure:"-"`
+
 	// Redis Configuration
 	RedisURL      string        `mapstructure:"redis_url"`
 	RedisPassword string        `mapstructure:"redis_password"`
@@ -21,6 +27,19 @@ type Config struct {
 	// Worker Configuration
 	WorkerID       string `mapstructure:"worker_id"`
 	ScraperType    string `mapstructure:"scraper_type"`
+
+	// ScraperBackend selects which registered scraper.Constructor (see
+	// scraper.Register) builds this worker's scraper, e.g. "jobspy" or a
+	// native per-site backend shipped as a sibling package. Empty defaults
+	// to "jobspy".
+	ScraperBackend string `mapstructure:"scraper_backend"`
+
+	// ScrapeRulesDir, if set, points at a directory of scraper/rules.Rule
+	// files (JSON or YAML) loaded at startup and hot-reloaded on change,
+	// letting operators fix broken selectors or add extracted fields
+	// without rebuilding the binary. Empty disables rule loading.
+	ScrapeRulesDir string `mapstructure:"scrape_rules_dir"`
+
 	Region         string `mapstructure:"region"`
 	Concurrency    int    `mapstructure:"concurrency"`
 	QueueTimeout   int    `mapstructure:"queue_timeout"`
@@ -28,7 +47,53 @@ type Config struct {
 	MaxRetries     int    `mapstructure:"max_retries"`
 	RetryDelay     int    `mapstructure:"retry_delay"`
 
+	// Two-phase cancellation: UpdateInterval is how long a worker can go
+	// without a heartbeat before it's considered stalled and soft-cancelled.
+	// ForceCancelInterval is the hard ceiling after which the orchestrator
+	// abandons the task outright (requeue-or-dead-letter) rather than wait
+	// for a hung scraper to ever unblock.
+	UpdateInterval      int `mapstructure:"update_interval"`
+	ForceCancelInterval int `mapstructure:"force_cancel_interval"`
+
+	// QueueBackend selects the task queue implementation: "list" (legacy
+	// LPUSH/BRPOP, a popped task is lost if the worker crashes before
+	// finishing it), "stream" (Redis Streams + consumer group, survives a
+	// crash via pending-entries reclaim), or "priority" (a single ZSET per
+	// scraper type ordered by ScrapingTask.Priority instead of "list"'s
+	// fixed urgent/normal/backfill bands). Defaults to "list" so existing
+	// deployments are unaffected.
+	QueueBackend string `mapstructure:"queue_backend"`
+
+	// PipePeriod, when non-zero, enables pipelined batch enqueue on the
+	// Redis client: PushTaskBatched queues writes on a shared pipeline
+	// flushed every PipePeriod instead of one round-trip per task. Zero
+	// (the default) disables batching.
+	PipePeriod time.Duration `mapstructure:"pipe_period"`
+
+	// OutputFormats, when non-empty, has each worker stream scraped jobs to
+	// a local output.SinkSet as well as Redis - one entry per sink to
+	// build, e.g. []string{"ndjson", "csv", "html"} (loadFromEnv reads
+	// this from a comma-separated OUTPUT_FORMATS env var). Empty disables
+	// file output entirely.
+	OutputFormats []string `mapstructure:"output_formats"`
+
+	// OutputDir is the directory output.SinkSet writes into, with each
+	// task getting its own subdirectory named after its TaskID. Empty
+	// disables file output even if OutputFormats is non-empty.
+	OutputDir string `mapstructure:"output_dir"`
+
+	// ScrapeDispatchInterval is the stagger window for
+	// worker.ScrapeScheduler: each site gets a deterministic phase offset
+	// within this interval, plus a little random jitter, so many workers
+	// processing the same site's tasks don't all start scraping at once.
+	// Zero disables dispatch staggering.
+	ScrapeDispatchInterval time.Duration `mapstructure:"scrape_dispatch_interval"`
+
 	// Anti-Detection Configuration
+
+	// ProxyPool is the static proxy list: the source behind the "static"
+	// ProxyProviderType (the default), and also the fallback used to seed
+	// a scraper/proxy.Pool reload when no dynamic provider is configured.
 	ProxyPool           []string      `mapstructure:"proxy_pool"`
 	UserAgents          []string      `mapstructure:"user_agents"`
 	MinDelay            time.Duration `mapstructure:"min_delay"`
@@ -38,6 +103,45 @@ type Config struct {
 	RotateProxies       bool          `mapstructure:"rotate_proxies"`
 	RotateUserAgents    bool          `mapstructure:"rotate_user_agents"`
 
+	// ProxyProviderType selects the scraper/proxy.Provider backing the
+	// proxy pool: "static" (default, ProxyPool), "file" (ProxySource is a
+	// path to a JSON proxy list, hot-reloaded via fsnotify), "dns"
+	// (ProxySource is a "service.proto.name" SRV name), or "http"
+	// (ProxySource is a discovery endpoint URL).
+	ProxyProviderType string `mapstructure:"proxy_provider_type"`
+	ProxySource       string `mapstructure:"proxy_source"`
+
+	// ProxyRotationStrategy selects how scraper/proxy.Pool picks among
+	// healthy proxies: "round_robin" (default), "weighted" (by Entry.Weight),
+	// "sticky_per_host", or "least_recently_failed".
+	ProxyRotationStrategy string `mapstructure:"proxy_rotation_strategy"`
+
+	// ProxyHealthCheckURL, if set, is HEAD-probed through a newly
+	// discovered proxy before scraper/proxy.Pool marks it healthy. Empty
+	// skips probing and trusts the provider outright.
+	ProxyHealthCheckURL string `mapstructure:"proxy_health_check_url"`
+
+	// ProxyPollInterval is how often scraper/proxy.Pool re-polls its
+	// Provider for a fresh proxy set.
+	ProxyPollInterval time.Duration `mapstructure:"proxy_poll_interval"`
+
+	// RateLimitRPM seeds the ratelimit.AdaptiveLimiter's ceiling for this
+	// worker's scraper backend: the refill rate it returns to on sustained
+	// success, not a rate it's held at outright (see
+	// ratelimit.AdaptiveLimiter.ReportOutcome for the AIMD adjustment).
+	RateLimitRPM int `mapstructure:"rate_limit_rpm"`
+
+	// RespectRetryAfter, when true, makes the adaptive rate limiter honor a
+	// response's Retry-After header exactly instead of relying solely on
+	// its own AIMD-computed backoff.
+	RespectRetryAfter bool `mapstructure:"respect_retry_after"`
+
+	// JobSpyAPIURL is the base URL of the JobSpy backend the "jobspy"
+	// scraper.Constructor calls (see jobspy_client.JobSpyAPIClient).
+	// Threaded into ScraperConfig.BaseURL; empty falls back to
+	// JobSpyAPIClient's own "http://localhost:8000" default.
+	JobSpyAPIURL string `mapstructure:"jobspy_api_url"`
+
 	// Performance Configuration
 	MaxIdleConns        int           `mapstructure:"max_idle_conns"`
 	MaxConnsPerHost     int           `mapstructure:"max_conns_per_host"`
@@ -51,6 +155,30 @@ type Config struct {
 	MetricsInterval      time.Duration `mapstructure:"metrics_interval"`
 	LogLevel             string        `mapstructure:"log_level"`
 
+	// MetricsAddr, when MetricsEnabled is true, is the address HealthMonitor
+	// binds its embedded /metrics HTTP server to (e.g. ":9090"), letting a
+	// Prometheus-style scraper pull a worker's metrics directly instead of
+	// only reading the Redis-pushed HealthStatus, which expires after
+	// HealthCheckInterval*2. Empty disables the embedded server even if
+	// MetricsEnabled is true.
+	MetricsAddr string `mapstructure:"metrics_addr"`
+
+	// MetricsPath is the HTTP path the embedded metrics server serves
+	// Prometheus text format on, e.g. "/metrics".
+	MetricsPath string `mapstructure:"metrics_path"`
+
+	// ProcessCPUThresholdPercent is the worker process's own CPU usage
+	// (not the host's) above which calculateHealthStatus reports
+	// "degraded", sampled via cpuSampler.
+	ProcessCPUThresholdPercent float64 `mapstructure:"process_cpu_threshold_percent"`
+
+	// PeerStalenessTimeout is how long a peer's gossiped HealthStatus (see
+	// HealthMonitor's peer-gossip subsystem) is trusted before it's evicted
+	// from GetClusterHealth's aggregate - a peer that stops publishing
+	// (crashed, network-partitioned) shouldn't keep being counted as part
+	// of quorum forever.
+	PeerStalenessTimeout time.Duration `mapstructure:"peer_staleness_timeout"`
+
 	// Site-Specific Configuration
 	IndeedConfig    IndeedConfig    `mapstructure:"indeed"`
 	LinkedInConfig  LinkedInConfig  `mapstructure:"linkedin"`
@@ -66,6 +194,7 @@ type IndeedConfig struct {
 	AllowedDomains  []string `mapstructure:"allowed_domains"`
 	RequestDelay    int      `mapstructure:"request_delay"`
 	RateLimitRPM    int      `mapstructure:"rate_limit_rpm"`
+	RespectRetryAfter bool   `mapstructure:"respect_retry_after"`
 }
 
 // LinkedInConfig holds LinkedIn-specific configuration
@@ -78,6 +207,7 @@ type LinkedInConfig struct {
 	RequestDelay    int      `mapstructure:"request_delay"`
 	RateLimitRPM    int      `mapstructure:"rate_limit_rpm"`
 	RequiresBrowser bool     `mapstructure:"requires_browser"`
+	RespectRetryAfter bool   `mapstructure:"respect_retry_after"`
 }
 
 // GlassdoorConfig holds Glassdoor-specific configuration
@@ -89,6 +219,7 @@ type GlassdoorConfig struct {
 	AllowedDomains  []string `mapstructure:"allowed_domains"`
 	RequestDelay    int      `mapstructure:"request_delay"`
 	RateLimitRPM    int      `mapstructure:"rate_limit_rpm"`
+	RespectRetryAfter bool   `mapstructure:"respect_retry_after"`
 }
 
 // DefaultConfig returns a configuration with sensible defaults
@@ -108,13 +239,24 @@ func DefaultConfig() *Config {
 		MaxRetries:   3,
 		RetryDelay:   60,
 
+		UpdateInterval:      60,
+		ForceCancelInterval: 600,
+		QueueBackend:        "list",
+		ScrapeDispatchInterval: 30 * time.Second,
+		RateLimitRPM:          60,
+		RespectRetryAfter:     true,
+		JobSpyAPIURL:          "http://localhost:8000",
+
 		// Anti-detection defaults
-		MinDelay:         1 * time.Second,
-		MaxDelay:         3 * time.Second,
-		BrowserMode:      false,
-		StealthMode:      true,
-		RotateProxies:    true,
-		RotateUserAgents: true,
+		MinDelay:              1 * time.Second,
+		MaxDelay:              3 * time.Second,
+		BrowserMode:           false,
+		StealthMode:           true,
+		RotateProxies:         true,
+		RotateUserAgents:      true,
+		ProxyProviderType:     "static",
+		ProxyRotationStrategy: "round_robin",
+		ProxyPollInterval:     30 * time.Second,
 
 		// Performance defaults
 		MaxIdleConns:        100,
@@ -128,6 +270,10 @@ func DefaultConfig() *Config {
 		HealthCheckInterval: 60 * time.Second,
 		MetricsInterval:     300 * time.Second,
 		LogLevel:            "info",
+		MetricsAddr:         ":9090",
+		MetricsPath:         "/metrics",
+		ProcessCPUThresholdPercent: 90.0,
+		PeerStalenessTimeout:       3 * time.Minute,
 
 		// Default user agents
 		UserAgents: []string{
@@ -147,6 +293,7 @@ func DefaultConfig() *Config {
 			AllowedDomains: []string{"indeed.com"},
 			RequestDelay:   2000,
 			RateLimitRPM:   30,
+			RespectRetryAfter: true,
 		},
 
 		LinkedInConfig: LinkedInConfig{
@@ -158,6 +305,7 @@ func DefaultConfig() *Config {
 			RequestDelay:    3000,
 			RateLimitRPM:    20,
 			RequiresBrowser: true,
+			RespectRetryAfter: true,
 		},
 
 		GlassdoorConfig: GlassdoorConfig{
@@ -168,6 +316,7 @@ func DefaultConfig() *Config {
 			AllowedDomains: []string{"glassdoor.com"},
 			RequestDelay:   2500,
 			RateLimitRPM:   25,
+			RespectRetryAfter: true,
 		},
 	}
 }
@@ -194,6 +343,8 @@ func LoadConfig() (*Config, error) {
 		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
 			return nil, fmt.Errorf("error reading config file: %w", err)
 		}
+	} else {
+		config.ConfigFilePath = viper.ConfigFileUsed()
 	}
 
 	// Unmarshal into config struct
@@ -239,11 +390,25 @@ func loadFromEnv(config *Config) error {
 	if region := os.Getenv("REGION"); region != "" {
 		config.Region = region
 	}
+	if rulesDir := os.Getenv("SCRAPE_RULES_DIR"); rulesDir != "" {
+		config.ScrapeRulesDir = rulesDir
+	}
 	if concurrency := os.Getenv("CONCURRENCY"); concurrency != "" {
 		if c, err := strconv.Atoi(concurrency); err == nil {
 			config.Concurrency = c
 		}
 	}
+	if rateLimitRPM := os.Getenv("RATE_LIMIT_RPM"); rateLimitRPM != "" {
+		if r, err := strconv.Atoi(rateLimitRPM); err == nil {
+			config.RateLimitRPM = r
+		}
+	}
+	if respectRetryAfter := os.Getenv("RESPECT_RETRY_AFTER"); respectRetryAfter != "" {
+		config.RespectRetryAfter = strings.ToLower(respectRetryAfter) == "true"
+	}
+	if jobSpyAPIURL := os.Getenv("JOBSPY_API_URL"); jobSpyAPIURL != "" {
+		config.JobSpyAPIURL = jobSpyAPIURL
+	}
 
 	// Proxy configuration
 	if proxyPool := os.Getenv("PROXY_POOL"); proxyPool != "" {
@@ -253,6 +418,17 @@ func loadFromEnv(config *Config) error {
 		}
 	}
 
+	// Output sink configuration
+	if outputFormats := os.Getenv("OUTPUT_FORMATS"); outputFormats != "" {
+		config.OutputFormats = strings.Split(outputFormats, ",")
+		for i, format := range config.OutputFormats {
+			config.OutputFormats[i] = strings.TrimSpace(format)
+		}
+	}
+	if outputDir := os.Getenv("OUTPUT_DIR"); outputDir != "" {
+		config.OutputDir = outputDir
+	}
+
 	// Performance tuning
 	if maxConns := os.Getenv("MAX_CONNS_PER_HOST"); maxConns != "" {
 		if c, err := strconv.Atoi(maxConns); err == nil {
@@ -267,6 +443,22 @@ func loadFromEnv(config *Config) error {
 	if metricsEnabled := os.Getenv("METRICS_ENABLED"); metricsEnabled != "" {
 		config.MetricsEnabled = strings.ToLower(metricsEnabled) == "true"
 	}
+	if metricsAddr := os.Getenv("METRICS_ADDR"); metricsAddr != "" {
+		config.MetricsAddr = metricsAddr
+	}
+	if metricsPath := os.Getenv("METRICS_PATH"); metricsPath != "" {
+		config.MetricsPath = metricsPath
+	}
+	if cpuThreshold := os.Getenv("PROCESS_CPU_THRESHOLD_PERCENT"); cpuThreshold != "" {
+		if v, err := strconv.ParseFloat(cpuThreshold, 64); err == nil {
+			config.ProcessCPUThresholdPercent = v
+		}
+	}
+	if peerStaleness := os.Getenv("PEER_STALENESS_TIMEOUT"); peerStaleness != "" {
+		if d, err := time.ParseDuration(peerStaleness); err == nil {
+			config.PeerStalenessTimeout = d
+		}
+	}
 
 	return nil
 }
@@ -301,6 +493,14 @@ func validateConfig(config *Config) error {
 		return fmt.Errorf("task_timeout must be between 30 and 3600 seconds")
 	}
 
+	if config.ForceCancelInterval <= config.UpdateInterval {
+		return fmt.Errorf("force_cancel_interval (%d) must be greater than update_interval (%d)", config.ForceCancelInterval, config.UpdateInterval)
+	}
+
+	if config.QueueBackend != "list" && config.QueueBackend != "stream" && config.QueueBackend != "priority" {
+		return fmt.Errorf("invalid queue_backend: %s, must be one of: [list stream priority]", config.QueueBackend)
+	}
+
 	validLogLevels := []string{"debug", "info", "warn", "error"}
 	isValidLogLevel := false
 	for _, level := range validLogLevels {
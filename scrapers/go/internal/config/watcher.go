@@ -0,0 +1,186 @@
+package config
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/sirupsen/logrus"
+)
+
+// ReloadOutcome is the result of a single hot-reload attempt, tracked so
+// operators can expose a config_reloads_total{outcome="success|failure"}
+// style counter.
+type ReloadOutcome string
+
+const (
+	ReloadSuccess ReloadOutcome = "success"
+	ReloadFailure ReloadOutcome = "failure"
+)
+
+// ImmutableFieldError is returned when a config reload attempts to change a
+// field that cannot be applied without a worker restart (scraper type,
+// worker ID, concurrency). The previous configuration stays in force.
+type ImmutableFieldError struct {
+	Field string
+	Old   interface{}
+	New   interface{}
+}
+
+func (e *ImmutableFieldError) Error() string {
+	return fmt.Sprintf("config field %q is immutable: %v -> %v requires a restart", e.Field, e.Old, e.New)
+}
+
+// Watcher observes the config file backing LoadConfig and pushes validated
+// reloads through Updates(). It tolerates editors that write via
+// rename-modify-delete by re-adding the fsnotify watch after every event
+// that could have removed it.
+type Watcher struct {
+	logger  *logrus.Logger
+	watcher *fsnotify.Watcher
+	path    string
+	current *Config
+	updates chan *Config
+	stopCh  chan struct{}
+
+	statsLock sync.Mutex
+	stats     map[ReloadOutcome]int64
+}
+
+// NewWatcher creates a Watcher for path, diffing future reloads against
+// current (the configuration already in force).
+func NewWatcher(path string, current *Config, logger *logrus.Logger) (*Watcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create fsnotify watcher: %w", err)
+	}
+
+	if err := fsw.Add(path); err != nil {
+		fsw.Close()
+		return nil, fmt.Errorf("failed to watch config file %s: %w", path, err)
+	}
+
+	return &Watcher{
+		logger:  logger,
+		watcher: fsw,
+		path:    path,
+		current: current,
+		updates: make(chan *Config, 1),
+		stopCh:  make(chan struct{}),
+		stats:   make(map[ReloadOutcome]int64),
+	}, nil
+}
+
+// Updates returns the channel new, validated configurations are delivered
+// on. The channel is buffered with size 1 and the newest reload wins if the
+// consumer hasn't drained the previous one yet.
+func (w *Watcher) Updates() <-chan *Config {
+	return w.updates
+}
+
+// Stats returns a snapshot of reload outcome counts.
+func (w *Watcher) Stats() map[ReloadOutcome]int64 {
+	w.statsLock.Lock()
+	defer w.statsLock.Unlock()
+
+	stats := make(map[ReloadOutcome]int64, len(w.stats))
+	for k, v := range w.stats {
+		stats[k] = v
+	}
+	return stats
+}
+
+// Run processes fsnotify events until Stop is called. It should be run in
+// its own goroutine.
+func (w *Watcher) Run() {
+	for {
+		select {
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			w.handleEvent(event)
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			w.logger.WithError(err).Warn("Config watcher error")
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+// Stop stops the watcher and releases the underlying fsnotify handle.
+func (w *Watcher) Stop() {
+	close(w.stopCh)
+	w.watcher.Close()
+}
+
+func (w *Watcher) handleEvent(event fsnotify.Event) {
+	// Many editors save via a temp-file rename or a delete+recreate, which
+	// drops the inode fsnotify was watching. Re-add the watch on any event
+	// that could have done that, before processing the reload itself.
+	if event.Op&(fsnotify.Remove|fsnotify.Rename) != 0 {
+		if err := w.watcher.Add(w.path); err != nil {
+			w.logger.WithError(err).Warn("Failed to re-add config watch after rename/remove")
+		}
+	}
+
+	if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Remove|fsnotify.Rename) == 0 {
+		return
+	}
+
+	w.reload()
+}
+
+func (w *Watcher) reload() {
+	newConfig, err := LoadConfig()
+	if err != nil {
+		w.recordOutcome(ReloadFailure)
+		w.logger.WithError(err).Error("Config reload failed, keeping previous configuration")
+		return
+	}
+
+	if err := diffImmutableFields(w.current, newConfig); err != nil {
+		w.recordOutcome(ReloadFailure)
+		w.logger.WithError(err).Error("Config reload rejected, keeping previous configuration")
+		return
+	}
+
+	w.recordOutcome(ReloadSuccess)
+	w.current = newConfig
+	w.logger.Info("Configuration hot-reloaded successfully")
+
+	select {
+	case w.updates <- newConfig:
+	default:
+		// Drain the stale pending update so the freshest config wins.
+		select {
+		case <-w.updates:
+		default:
+		}
+		w.updates <- newConfig
+	}
+}
+
+func (w *Watcher) recordOutcome(outcome ReloadOutcome) {
+	w.statsLock.Lock()
+	defer w.statsLock.Unlock()
+	w.stats[outcome]++
+}
+
+// diffImmutableFields rejects a reload that changes a field which cannot be
+// applied to already-running workers.
+func diffImmutableFields(old, new *Config) error {
+	if old.ScraperType != new.ScraperType {
+		return &ImmutableFieldError{Field: "scraper_type", Old: old.ScraperType, New: new.ScraperType}
+	}
+	if old.WorkerID != new.WorkerID {
+		return &ImmutableFieldError{Field: "worker_id", Old: old.WorkerID, New: new.WorkerID}
+	}
+	if old.Concurrency != new.Concurrency {
+		return &ImmutableFieldError{Field: "concurrency", Old: old.Concurrency, New: new.Concurrency}
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jobspy/scrapers/internal/protocol"
+)
+
+// csvColumns is the stable column schema every CSVSink writes, derived from
+// protocol.JobData's fields in declaration order. This is an explicit,
+// ordered list rather than a reflection-derived one, so adding a JobData
+// field doesn't silently reorder or break an existing consumer's column
+// assumptions.
+var csvColumns = []string{
+	"title", "company", "location", "job_url", "description", "posted_date",
+	"salary_min", "salary_max", "salary_currency", "job_type",
+	"experience_level", "is_remote", "apply_url", "easy_apply",
+	"company_logo", "job_hash", "external_job_id", "skills", "benefits",
+	"requirements",
+}
+
+// CSVSink writes one row per job using the fixed csvColumns schema.
+type CSVSink struct {
+	w *rotatingWriter
+}
+
+// NewCSVSink builds a CSVSink rooted at dir. Each rotated file gets its own
+// header row, so it's independently readable by any plain CSV reader.
+func NewCSVSink(dir string, maxBytes int64, maxAge time.Duration) *CSVSink {
+	header := func(w io.Writer) error {
+		cw := csv.NewWriter(w)
+		if err := cw.Write(csvColumns); err != nil {
+			return err
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+	return &CSVSink{w: newRotatingWriter(dir, "csv", maxBytes, maxAge, header)}
+}
+
+func (s *CSVSink) Name() string { return "csv" }
+
+func (s *CSVSink) WriteJob(job protocol.JobData) error {
+	var buf bytes.Buffer
+	cw := csv.NewWriter(&buf)
+	if err := cw.Write(jobToRow(job)); err != nil {
+		return fmt.Errorf("failed to encode job for csv sink: %w", err)
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to encode job for csv sink: %w", err)
+	}
+
+	if err := s.w.write(buf.Bytes()); err != nil {
+		return err
+	}
+	s.w.recordJob()
+	return nil
+}
+
+func (s *CSVSink) Close() ([]ManifestEntry, error) { return s.w.close() }
+
+func jobToRow(job protocol.JobData) []string {
+	return []string{
+		job.Title,
+		job.Company,
+		job.Location,
+		job.JobURL,
+		job.Description,
+		strPtr(job.PostedDate),
+		floatPtr(job.SalaryMin),
+		floatPtr(job.SalaryMax),
+		job.SalaryCurrency,
+		strPtr(job.JobType),
+		strPtr(job.ExperienceLevel),
+		strconv.FormatBool(job.IsRemote),
+		strPtr(job.ApplyURL),
+		strconv.FormatBool(job.EasyApply),
+		strPtr(job.CompanyLogo),
+		strPtr(job.JobHash),
+		strPtr(job.ExternalJobID),
+		strings.Join(job.Skills, ";"),
+		strings.Join(job.Benefits, ";"),
+		strPtr(job.Requirements),
+	}
+}
+
+func strPtr(p *string) string {
+	if p == nil {
+		return ""
+	}
+	return *p
+}
+
+func floatPtr(p *float64) string {
+	if p == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*p, 'f', -1, 64)
+}
@@ -0,0 +1,129 @@
+package output
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/jobspy/scrapers/internal/protocol"
+)
+
+// htmlReportTemplate renders a single self-contained HTML file: an embedded
+// <style> block and a client-side-filterable <table> (a plain <input>
+// wired up with a few lines of vanilla JS, no external assets, so the file
+// works offline), one row per job.
+const htmlReportTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Scrape report</title>
+<style>
+body { font-family: sans-serif; margin: 2rem; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border: 1px solid #ccc; padding: 0.4rem 0.6rem; text-align: left; font-size: 0.85rem; }
+th { background: #f0f0f0; position: sticky; top: 0; }
+tr:nth-child(even) { background: #fafafa; }
+#filter { margin-bottom: 1rem; padding: 0.4rem; width: 100%; max-width: 400px; }
+</style>
+</head>
+<body>
+<h1>Scrape report ({{len .Jobs}} jobs)</h1>
+<input id="filter" type="text" placeholder="Filter rows...">
+<table id="jobs">
+<thead><tr><th>Title</th><th>Company</th><th>Location</th><th>Type</th><th>Remote</th><th>Salary</th><th>URL</th></tr></thead>
+<tbody>
+{{range .Jobs}}<tr><td>{{.Title}}</td><td>{{.Company}}</td><td>{{.Location}}</td><td>{{deref .JobType}}</td><td>{{.IsRemote}}</td><td>{{.SalaryMin}}-{{.SalaryMax}} {{.SalaryCurrency}}</td><td><a href="{{.JobURL}}">{{.JobURL}}</a></td></tr>
+{{end}}
+</tbody>
+</table>
+<script>
+document.getElementById("filter").addEventListener("input", function(e) {
+  var q = e.target.value.toLowerCase();
+  document.querySelectorAll("#jobs tbody tr").forEach(function(row) {
+    row.style.display = row.textContent.toLowerCase().includes(q) ? "" : "none";
+  });
+});
+</script>
+</body>
+</html>
+`
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"deref": func(p *string) string {
+		if p == nil {
+			return ""
+		}
+		return *p
+	},
+}).Parse(htmlReportTemplate))
+
+// HTMLSink accumulates job records in memory as they arrive - an HTML
+// summary report isn't meaningfully appendable the way NDJSON/CSV lines
+// are - and renders a single self-contained report on Close. Unlike the
+// other sinks it never rotates: one run produces one report.html.
+type HTMLSink struct {
+	dir string
+
+	mu   sync.Mutex
+	jobs []protocol.JobData
+}
+
+// NewHTMLSink builds an HTMLSink that writes report.html into dir on Close.
+func NewHTMLSink(dir string) *HTMLSink {
+	return &HTMLSink{dir: dir}
+}
+
+func (s *HTMLSink) Name() string { return "html" }
+
+func (s *HTMLSink) WriteJob(job protocol.JobData) error {
+	s.mu.Lock()
+	s.jobs = append(s.jobs, job)
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *HTMLSink) Close() ([]ManifestEntry, error) {
+	s.mu.Lock()
+	jobs := s.jobs
+	s.mu.Unlock()
+
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create output dir %s: %w", s.dir, err)
+	}
+
+	path := filepath.Join(s.dir, "report.html")
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create html report %s: %w", path, err)
+	}
+	defer f.Close()
+
+	hasher := sha256.New()
+	tee := io.MultiWriter(f, hasher)
+	openedAt := time.Now()
+
+	if err := htmlTemplate.Execute(tee, struct{ Jobs []protocol.JobData }{Jobs: jobs}); err != nil {
+		return nil, fmt.Errorf("failed to render html report: %w", err)
+	}
+
+	var size int64
+	if info, statErr := os.Stat(path); statErr == nil {
+		size = info.Size()
+	}
+
+	return []ManifestEntry{{
+		Format:   "html",
+		Path:     path,
+		Count:    len(jobs),
+		Bytes:    size,
+		SHA256:   hex.EncodeToString(hasher.Sum(nil)),
+		OpenedAt: openedAt,
+		ClosedAt: time.Now(),
+	}}, nil
+}
@@ -0,0 +1,39 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jobspy/scrapers/internal/protocol"
+)
+
+// NDJSONSink writes one JSON object per line per job - the simplest sink to
+// consume with a streaming reader (jq -c, tail -f, etc).
+type NDJSONSink struct {
+	w *rotatingWriter
+}
+
+// NewNDJSONSink builds an NDJSONSink rooted at dir, rotating to a new file
+// once the current one exceeds maxBytes or maxAge (either may be zero to
+// disable that trigger).
+func NewNDJSONSink(dir string, maxBytes int64, maxAge time.Duration) *NDJSONSink {
+	return &NDJSONSink{w: newRotatingWriter(dir, "ndjson", maxBytes, maxAge, nil)}
+}
+
+func (s *NDJSONSink) Name() string { return "ndjson" }
+
+func (s *NDJSONSink) WriteJob(job protocol.JobData) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to marshal job for ndjson sink: %w", err)
+	}
+
+	if err := s.w.write(append(data, '\n')); err != nil {
+		return err
+	}
+	s.w.recordJob()
+	return nil
+}
+
+func (s *NDJSONSink) Close() ([]ManifestEntry, error) { return s.w.close() }
@@ -0,0 +1,68 @@
+package output
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/jobspy/scrapers/internal/protocol"
+)
+
+// Uploader ships one finished output file to an object store. This package
+// ships no concrete S3/GCS implementation - doing so would pull the AWS or
+// Google Cloud SDKs into a worker binary that otherwise depends on nothing
+// heavier than Redis/Viper/logrus/Prometheus clients - so a RemoteSink only
+// becomes usable once an embedding application supplies one, e.g. backed by
+// the AWS SDK's s3.Client or Google Cloud Storage's storage.Writer.
+type Uploader interface {
+	Upload(ctx context.Context, key string, body io.Reader) error
+}
+
+// RemoteSink wraps an underlying local Sink (typically an NDJSONSink) and
+// uploads each of its finished files via Uploader once Close runs, so a
+// downstream pipeline can consume results from object storage without
+// touching Redis. Per-job writes only go to the local sink; the remote
+// upload happens once per finished file, not once per job.
+type RemoteSink struct {
+	ctx      context.Context
+	local    Sink
+	uploader Uploader
+	prefix   string
+}
+
+// NewRemoteSink wraps local with uploader, prefixing each uploaded object
+// key with prefix (e.g. a bucket "directory" per run).
+func NewRemoteSink(ctx context.Context, local Sink, uploader Uploader, prefix string) *RemoteSink {
+	return &RemoteSink{ctx: ctx, local: local, uploader: uploader, prefix: prefix}
+}
+
+func (s *RemoteSink) Name() string { return "remote:" + s.local.Name() }
+
+func (s *RemoteSink) WriteJob(job protocol.JobData) error {
+	return s.local.WriteJob(job)
+}
+
+func (s *RemoteSink) Close() ([]ManifestEntry, error) {
+	entries, err := s.local.Close()
+	if err != nil {
+		return entries, err
+	}
+
+	for i, entry := range entries {
+		f, openErr := os.Open(entry.Path)
+		if openErr != nil {
+			return entries, fmt.Errorf("failed to open %s for upload: %w", entry.Path, openErr)
+		}
+
+		key := s.prefix + "/" + filepath.Base(entry.Path)
+		uploadErr := s.uploader.Upload(s.ctx, key, f)
+		f.Close()
+		if uploadErr != nil {
+			return entries, fmt.Errorf("failed to upload %s: %w", entry.Path, uploadErr)
+		}
+		entries[i].Path = key
+	}
+	return entries, nil
+}
@@ -0,0 +1,131 @@
+package output
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// rotatingWriter is the shared rotate-by-size/time file roller behind the
+// NDJSON and CSV sinks, so neither has to reimplement rotation and checksum
+// bookkeeping itself.
+type rotatingWriter struct {
+	dir       string
+	format    string // file extension and ManifestEntry.Format, e.g. "ndjson"
+	maxBytes  int64  // 0 disables the size trigger
+	maxAge    time.Duration
+	onNewFile func(io.Writer) error // e.g. writes a CSV header row
+
+	file     *os.File
+	hasher   hash.Hash
+	tee      io.Writer
+	bytes    int64
+	count    int
+	openedAt time.Time
+	seq      int
+	entries  []ManifestEntry
+}
+
+func newRotatingWriter(dir, format string, maxBytes int64, maxAge time.Duration, onNewFile func(io.Writer) error) *rotatingWriter {
+	return &rotatingWriter{dir: dir, format: format, maxBytes: maxBytes, maxAge: maxAge, onNewFile: onNewFile}
+}
+
+// ensureOpen opens the first file, or rotates to a new one if the current
+// file has exceeded maxBytes or maxAge.
+func (w *rotatingWriter) ensureOpen() error {
+	if w.file != nil {
+		exceededSize := w.maxBytes > 0 && w.bytes >= w.maxBytes
+		exceededAge := w.maxAge > 0 && time.Since(w.openedAt) >= w.maxAge
+		if !exceededSize && !exceededAge {
+			return nil
+		}
+		if err := w.closeCurrent(); err != nil {
+			return err
+		}
+	}
+	return w.open()
+}
+
+func (w *rotatingWriter) open() error {
+	if err := os.MkdirAll(w.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create output dir %s: %w", w.dir, err)
+	}
+
+	w.seq++
+	path := filepath.Join(w.dir, fmt.Sprintf("%s-%03d.%s", w.format, w.seq, w.format))
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create output file %s: %w", path, err)
+	}
+
+	w.file = f
+	w.hasher = sha256.New()
+	w.tee = io.MultiWriter(f, w.hasher)
+	w.bytes = 0
+	w.count = 0
+	w.openedAt = time.Now()
+
+	if w.onNewFile != nil {
+		if err := w.onNewFile(w.tee); err != nil {
+			return fmt.Errorf("failed to write header to %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func (w *rotatingWriter) write(p []byte) error {
+	if err := w.ensureOpen(); err != nil {
+		return err
+	}
+	n, err := w.tee.Write(p)
+	w.bytes += int64(n)
+	if err != nil {
+		return fmt.Errorf("failed to write to output file: %w", err)
+	}
+	return nil
+}
+
+func (w *rotatingWriter) recordJob() { w.count++ }
+
+func (w *rotatingWriter) closeCurrent() error {
+	if w.file == nil {
+		return nil
+	}
+
+	path := w.file.Name()
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("failed to close output file %s: %w", path, err)
+	}
+
+	var size int64
+	if info, err := os.Stat(path); err == nil {
+		size = info.Size()
+	}
+
+	w.entries = append(w.entries, ManifestEntry{
+		Format:   w.format,
+		Path:     path,
+		Count:    w.count,
+		Bytes:    size,
+		SHA256:   hex.EncodeToString(w.hasher.Sum(nil)),
+		OpenedAt: w.openedAt,
+		ClosedAt: time.Now(),
+	})
+
+	w.file = nil
+	w.hasher = nil
+	w.tee = nil
+	return nil
+}
+
+func (w *rotatingWriter) close() ([]ManifestEntry, error) {
+	if err := w.closeCurrent(); err != nil {
+		return nil, err
+	}
+	return w.entries, nil
+}
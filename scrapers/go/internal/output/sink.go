@@ -0,0 +1,40 @@
+// Package output implements pluggable sinks for streaming normalized
+// protocol.JobData records out of a worker as they're scraped, instead of
+// only via Redis - mirroring the multi-output-format convention common in
+// scanner tooling (ffuf's -o/-of, for instance), so a downstream pipeline
+// can consume NDJSON, CSV, or an HTML report directly from disk (or object
+// storage, via RemoteSink) without needing to speak to Redis at all.
+package output
+
+import (
+	"time"
+
+	"github.com/jobspy/scrapers/internal/protocol"
+)
+
+// Sink receives normalized job records one at a time, as soon as each is
+// decoded, rather than buffering an entire run before writing anything.
+type Sink interface {
+	// Name identifies this sink in its ManifestEntry output, e.g. "ndjson".
+	Name() string
+
+	// WriteJob appends one job record to the sink's current output.
+	WriteJob(job protocol.JobData) error
+
+	// Close flushes and finalizes any open output file(s) and returns one
+	// ManifestEntry per file this sink produced during its lifetime.
+	Close() ([]ManifestEntry, error)
+}
+
+// ManifestEntry describes one file a Sink produced, recorded in a run's
+// manifest.json so a downstream pipeline can validate what it received
+// without touching Redis.
+type ManifestEntry struct {
+	Format   string    `json:"format"`
+	Path     string    `json:"path"`
+	Count    int       `json:"count"`
+	Bytes    int64     `json:"bytes"`
+	SHA256   string    `json:"sha256"`
+	OpenedAt time.Time `json:"opened_at"`
+	ClosedAt time.Time `json:"closed_at"`
+}
@@ -0,0 +1,103 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jobspy/scrapers/internal/protocol"
+)
+
+// defaultRotateMaxBytes/defaultRotateMaxAge bound how large or how long a
+// rotatable sink's current file can grow before a new one starts.
+const (
+	defaultRotateMaxBytes = 100 * 1024 * 1024 // 100MB
+	defaultRotateMaxAge   = 15 * time.Minute
+)
+
+// SinkSet fans a run's job records out to every configured Sink and
+// collects their ManifestEntry results into a single manifest.json on
+// Close.
+type SinkSet struct {
+	dir   string
+	sinks []Sink
+}
+
+// NewSinkSet builds a SinkSet from a list of formats (e.g. the
+// comma-separated OUTPUT_FORMATS config value already split by the caller)
+// rooted at dir - typically config.OutputDir joined with a per-run
+// subdirectory. An empty formats list returns a SinkSet with no sinks, so
+// callers don't need to special-case "output disabled" themselves -
+// WriteJob/Close are simply no-ops.
+func NewSinkSet(formats []string, dir string) (*SinkSet, error) {
+	set := &SinkSet{dir: dir}
+	for _, format := range formats {
+		format = strings.ToLower(strings.TrimSpace(format))
+		if format == "" {
+			continue
+		}
+		switch format {
+		case "ndjson":
+			set.sinks = append(set.sinks, NewNDJSONSink(dir, defaultRotateMaxBytes, defaultRotateMaxAge))
+		case "csv":
+			set.sinks = append(set.sinks, NewCSVSink(dir, defaultRotateMaxBytes, defaultRotateMaxAge))
+		case "html":
+			set.sinks = append(set.sinks, NewHTMLSink(dir))
+		case "s3", "gcs":
+			return nil, fmt.Errorf("output format %q requires a RemoteSink wired up with an output.Uploader by the embedding application; none is configured in this build", format)
+		default:
+			return nil, fmt.Errorf("unknown output format %q", format)
+		}
+	}
+	return set, nil
+}
+
+// WriteJob fans job out to every configured sink, collecting (not stopping
+// on) the first error so one misbehaving sink doesn't keep the others from
+// recording a job they'd have handled fine.
+func (s *SinkSet) WriteJob(job protocol.JobData) error {
+	var firstErr error
+	for _, sink := range s.sinks {
+		if err := sink.WriteJob(job); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("sink %s: %w", sink.Name(), err)
+		}
+	}
+	return firstErr
+}
+
+// Close finalizes every sink and writes a manifest.json alongside their
+// output files summarizing what each produced.
+func (s *SinkSet) Close() error {
+	if len(s.sinks) == 0 {
+		return nil
+	}
+
+	var allEntries []ManifestEntry
+	var firstErr error
+	for _, sink := range s.sinks {
+		entries, err := sink.Close()
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("sink %s: %w", sink.Name(), err)
+		}
+		allEntries = append(allEntries, entries...)
+	}
+
+	manifestPath := filepath.Join(s.dir, "manifest.json")
+	data, err := json.MarshalIndent(struct {
+		Files []ManifestEntry `json:"files"`
+	}{Files: allEntries}, "", "  ")
+	if err != nil {
+		if firstErr == nil {
+			firstErr = fmt.Errorf("failed to marshal manifest: %w", err)
+		}
+		return firstErr
+	}
+
+	if err := os.WriteFile(manifestPath, data, 0o644); err != nil && firstErr == nil {
+		firstErr = fmt.Errorf("failed to write manifest %s: %w", manifestPath, err)
+	}
+	return firstErr
+}
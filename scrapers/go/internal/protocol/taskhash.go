@@ -0,0 +1,61 @@
+package protocol
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ComputeTaskHash derives a stable, FFUFHASH-style fingerprint for a task
+// from the inputs that make two dispatches "the same piece of work":
+// scraper type, normalized search term/location, page index, region, and
+// the rule-set generation (see rules.RuleSet.Version) active when it was
+// dispatched. Unlike the dedup hash in redis.PushTaskUnique - which only
+// needs to catch an accidental replay - this hash is meant to be shared
+// outside the process (as the X-Task-Hash header and in callback URLs), so
+// it also folds in region and rule_version to disambiguate otherwise
+// identical search requests handled by different workers or rule
+// generations.
+//
+// pageIndex is always 0 in this worker: the JobSpy API backend paginates
+// internally rather than this client issuing one task per page, so there is
+// currently only ever one page per task. The parameter exists so the hash
+// keeps its meaning unchanged if a future backend splits a task per page.
+func ComputeTaskHash(scraperType ScraperType, params ScrapingTaskParams, pageIndex int, region, ruleVersion string) string {
+	fields := []string{
+		string(scraperType),
+		normalizeQueryField(params.SearchTerm),
+		normalizeQueryField(params.Location),
+		strconv.Itoa(pageIndex),
+		normalizeQueryField(region),
+		ruleVersion,
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(fields, "|")))
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeQueryField lower-cases and trims a query field before it goes
+// into ComputeTaskHash, so "Software Engineer" and "software engineer  "
+// hash identically.
+func normalizeQueryField(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// InjectTaskHash appends hash to rawURL as a task_hash query parameter,
+// preserving any query parameters already present. It returns rawURL
+// unchanged if rawURL fails to parse as a URL, since a malformed callback
+// URL shouldn't block dispatching the task itself.
+func InjectTaskHash(rawURL, hash string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	q := u.Query()
+	q.Set("task_hash", hash)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
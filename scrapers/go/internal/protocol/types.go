@@ -43,6 +43,24 @@ type ScrapingTaskParams struct {
 	UserAgent       *string  `json:"user_agent,omitempty"`
 	DelayRange      []int    `json:"delay_range,omitempty"`
 	PageLimit       int      `json:"page_limit"`
+
+	// TaskHash is a deterministic fingerprint of this task (see
+	// ComputeTaskHash), set by the worker before the scraper backend is
+	// invoked. It rides along in Params rather than only on ScrapingTask
+	// because ScrapeJobs/ScrapeJobsStream only ever see Params, not the
+	// enclosing task - and a scraper backend (e.g. jobspy_client) needs it
+	// to stamp the X-Task-Hash header on its own outbound request.
+	TaskHash string `json:"task_hash,omitempty"`
+
+	// CallbackURL, if set, is a webhook the task submitter wants notified
+	// once the task completes. This worker doesn't deliver the webhook
+	// itself (results are published via PushTask/PublishResult/TaskUpdate
+	// for whatever owns the submitter-facing API to forward); TaskHash is
+	// still injected into it via InjectTaskHash before the task is
+	// dispatched, so a downstream delivery step - and the eventual
+	// recipient - can correlate the callback back to this task by hash
+	// alone without needing the task ID.
+	CallbackURL *string `json:"callback_url,omitempty"`
 }
 
 // ScrapingTask represents a task to be executed by a scraper
@@ -55,6 +73,13 @@ type ScrapingTask struct {
 	RetryCount  int                 `json:"retry_count"`
 	MaxRetries  int                 `json:"max_retries"`
 	Priority    int                 `json:"priority"`
+
+	// StreamMessageID is the Redis Stream entry ID this task was delivered
+	// as, when QueueBackendStream is in use. Empty for the list backend.
+	// The worker must echo it back so the orchestrator can XACK (or, for an
+	// abandoned task, leave it pending for ClaimStalePending) once the
+	// ScrapingResult is published.
+	StreamMessageID string `json:"stream_message_id,omitempty"`
 }
 
 // JobData represents individual job data from scraping
@@ -81,18 +106,38 @@ type JobData struct {
 	Requirements    *string  `json:"requirements,omitempty"`
 }
 
+// DecodeIssue records one field that failed to decode as expected while
+// converting a scraper's raw API response into protocol types: the field
+// path (plus Index, for the entry's position in a list), the offending raw
+// value, and what went wrong. Aggregating these onto ScrapingMetadata lets
+// a caller tell "500 jobs decoded but 3 had a malformed MIN_AMOUNT" instead
+// of those 3 silently becoming zero values.
+type DecodeIssue struct {
+	Index   int    `json:"index"`
+	Field   string `json:"field"`
+	Value   string `json:"value"`
+	Message string `json:"message"`
+}
+
 // ScrapingMetadata holds metadata about the scraping execution
 type ScrapingMetadata struct {
-	ProxyUsed             *string `json:"proxy_used,omitempty"`
-	UserAgentUsed         *string `json:"user_agent_used,omitempty"`
-	RequestsMade          int     `json:"requests_made"`
-	PagesScraped          int     `json:"pages_scraped"`
-	RateLimited           bool    `json:"rate_limited"`
-	CaptchaEncountered    bool    `json:"captcha_encountered"`
-	BlockedRequests       int     `json:"blocked_requests"`
-	AverageResponseTime   float64 `json:"average_response_time"`
-	MemoryUsageMB         float64 `json:"memory_usage_mb"`
+	ProxyUsed             *string       `json:"proxy_used,omitempty"`
+	UserAgentUsed         *string       `json:"user_agent_used,omitempty"`
+	RequestsMade          int           `json:"requests_made"`
+	PagesScraped          int           `json:"pages_scraped"`
+	RateLimited           bool          `json:"rate_limited"`
+	CaptchaEncountered    bool          `json:"captcha_encountered"`
+	BlockedRequests       int           `json:"blocked_requests"`
+	AverageResponseTime   float64       `json:"average_response_time"`
+	MemoryUsageMB         float64       `json:"memory_usage_mb"`
+	DecodeWarnings        []DecodeIssue `json:"decode_warnings,omitempty"`
 	WorkerID              *string `json:"worker_id,omitempty"`
+
+	// AppliedRules lists the "site/page_type" extraction rules (see the
+	// scraper/rules package) that matched at least one job in this task,
+	// so an operator can tell which rule file - and therefore which
+	// selector version - produced the data without digging through logs.
+	AppliedRules []string `json:"applied_rules,omitempty"`
 }
 
 // ScrapingResult represents the result of a scraping task
@@ -108,6 +153,23 @@ type ScrapingResult struct {
 	Error         *string           `json:"error,omitempty"`
 }
 
+// ErrorCategory classifies why a task failed, letting HealthMonitor track
+// per-category error rates (see TaskMetrics.ErrorsByCategory) instead of
+// lumping every failure into one generic ErrorRateLastHour - a spike in
+// CategoryRateLimit calls for backing off new task assignments, while a
+// spike in CategoryParse calls for an engineer, not a pause.
+type ErrorCategory string
+
+const (
+	CategoryRateLimit ErrorCategory = "rate_limit"
+	CategoryBlocked   ErrorCategory = "blocked"
+	CategoryNetwork   ErrorCategory = "network"
+	CategoryParse     ErrorCategory = "parse"
+	CategoryTimeout   ErrorCategory = "timeout"
+	CategoryAuth      ErrorCategory = "auth"
+	CategoryUnknown   ErrorCategory = "unknown"
+)
+
 // HealthStatus represents the health status of a scraper worker
 type HealthStatus struct {
 	WorkerID                 string      `json:"worker_id"`
@@ -118,21 +180,91 @@ type HealthStatus struct {
 	ErrorRateLastHour        float64     `json:"error_rate_last_hour"`
 	MemoryUsageMB            float64     `json:"memory_usage_mb"`
 	CPUUsagePercent          float64     `json:"cpu_usage_percent"`
+	ProcessCPUPercent        float64     `json:"process_cpu_percent"`
+	LoadAverage1m            float64     `json:"load_average_1m"`
+	OpenFDs                  int         `json:"open_fds"`
 	ProxyPoolSize            int         `json:"proxy_pool_size"`
 	ProxySuccessRate         float64     `json:"proxy_success_rate"`
+
+	// P50/P95/P99ResponseTimeSeconds are task response time percentiles over
+	// the last hour, computed from a ring-buffered latency histogram (see
+	// worker.metricsRingBuffer) rather than just the mean an
+	// ErrorRateLastHour-style average would hide a long tail behind.
+	P50ResponseTimeSeconds float64 `json:"p50_response_time_seconds"`
+	P95ResponseTimeSeconds float64 `json:"p95_response_time_seconds"`
+	P99ResponseTimeSeconds float64 `json:"p99_response_time_seconds"`
+
+	// ErrorsByCategory is the last hour's failed task count per
+	// ErrorCategory. SuggestedBackoffSeconds, when nonzero, is how long a
+	// dispatcher (see HealthMonitor.calculateHealthStatus) should pause
+	// assigning new tasks to this worker, driven by a CategoryRateLimit
+	// spike rather than the generic error rate.
+	ErrorsByCategory        map[ErrorCategory]int `json:"errors_by_category,omitempty"`
+	SuggestedBackoffSeconds float64               `json:"suggested_backoff_seconds,omitempty"`
 	LastSuccessfulScrape     string      `json:"last_successful_scrape"`
 	Timestamp                string      `json:"timestamp"`
+
+	// BrokerReachable/LastBrokerErr reflect the most recent HealthMonitor
+	// broker connectivity probe (redis.Client.Ping), independent of
+	// whether the worker has completed any tasks recently - a worker can
+	// be idle-but-healthy or active-but-broker-down, and the two
+	// shouldn't be conflated.
+	BrokerReachable bool   `json:"broker_reachable"`
+	LastBrokerErr   string `json:"last_broker_err,omitempty"`
 }
 
 // ErrorReport represents an error report from a scraper
 type ErrorReport struct {
 	TaskID      string                 `json:"task_id"`
 	ScraperType ScraperType            `json:"scraper_type"`
+	Category    ErrorCategory          `json:"category,omitempty"`
 	Error       string                 `json:"error"`
 	Metadata    map[string]interface{} `json:"metadata"`
 	Timestamp   string                 `json:"timestamp"`
 }
 
+// TaskUpdateType identifies the kind of incremental update a running scrape
+// is reporting while it executes.
+type TaskUpdateType string
+
+const (
+	TaskUpdateProgress  TaskUpdateType = "progress"
+	TaskUpdateLog       TaskUpdateType = "log"
+	TaskUpdateHeartbeat TaskUpdateType = "heartbeat"
+	TaskUpdateCompleted TaskUpdateType = "completed"
+	TaskUpdateFailed    TaskUpdateType = "failed"
+)
+
+// TaskUpdate is an incremental message emitted while a task is still
+// running, published over the per-task pub/sub channel so a submitter can
+// render live progress instead of waiting for the final ScrapingResult. A
+// TaskUpdate of type completed/failed is always the last message published
+// for a given task.
+type TaskUpdate struct {
+	TaskID       string         `json:"task_id"`
+	Type         TaskUpdateType `json:"type"`
+	PagesScraped int            `json:"pages_scraped,omitempty"`
+	JobsBatch    []JobData      `json:"jobs_batch,omitempty"`
+	LogLines     []string       `json:"log_lines,omitempty"`
+	Error        *string        `json:"error,omitempty"`
+	Timestamp    string         `json:"timestamp"`
+}
+
+// GetTaskUpdateChannel returns the Redis pub/sub channel a task's
+// incremental TaskUpdate messages are published to.
+func GetTaskUpdateChannel(taskID string) string {
+	return "scraping:task_updates:" + taskID
+}
+
+// GetJobsStreamKey returns the Redis Stream a task's individual JobData
+// entries are XADDed to as they're scraped, letting a downstream consumer
+// start working on them before the task finishes. Unlike
+// GetTaskUpdateChannel's pub/sub, this is a durable stream: a consumer that
+// wasn't listening yet can still read it from the start.
+func GetJobsStreamKey(taskID string) string {
+	return "jobs:" + taskID
+}
+
 // Redis channel names for communication
 const (
 	ChannelScrapingTasks   = "scraping:tasks"
@@ -140,21 +272,102 @@ const (
 	ChannelHealthMonitor   = "scrapers:health"
 	ChannelErrorReporting  = "scrapers:errors"
 	ChannelWorkerCommands  = "scrapers:commands"
+	ChannelWorkerHeartbeat = "scrapers:heartbeat"
+	ChannelSchedulerLeader = "scrapers:scheduler:leader"
+)
+
+// TaskPriority represents the priority band a task is dispatched under
+type TaskPriority string
+
+const (
+	PriorityUrgent   TaskPriority = "urgent"
+	PriorityNormal   TaskPriority = "normal"
+	PriorityBackfill TaskPriority = "backfill"
 )
 
-// GetTaskQueue returns the Redis queue name for a specific scraper type
+// PriorityWeights holds the default weighted fair-queueing credits for each
+// priority band, refilled once per second by the dispatcher. The 8:4:1 ratio
+// means a sustained backfill-only producer still gets scheduled, but never at
+// the expense of urgent/normal throughput.
+var PriorityWeights = map[TaskPriority]int{
+	PriorityUrgent:   8,
+	PriorityNormal:   4,
+	PriorityBackfill: 1,
+}
+
+// AllPriorities lists priority bands from highest to lowest, used whenever
+// code needs a stable iteration order (credit refill, queue fan-out, etc).
+var AllPriorities = []TaskPriority{PriorityUrgent, PriorityNormal, PriorityBackfill}
+
+// WorkerHeartbeat is published periodically so the scheduler's leader can
+// discover live workers and their current load without relying on the
+// TTL'd HealthStatus key (which is scraper-type scoped, not per-worker).
+type WorkerHeartbeat struct {
+	WorkerID     string `json:"worker_id"`
+	ScraperType  string `json:"scraper_type"`
+	Region       string `json:"region"`
+	ActiveTasks  int    `json:"active_tasks"`
+	TasksHandled int64  `json:"tasks_handled"`
+	Timestamp    string `json:"timestamp"`
+}
+
+// GetTaskQueue returns the Redis queue name for a specific scraper type.
+// The scraper type is wrapped in a {} hash tag so every key derived from it
+// (priority bands, the consumer group, the delayed-retry and dead-letter
+// queues) hashes to the same Redis Cluster slot - required for the Lua
+// scripts and transactions that touch more than one of these keys at once.
 func GetTaskQueue(scraperType ScraperType) string {
-	return ChannelScrapingTasks + ":" + string(scraperType)
+	return ChannelScrapingTasks + ":{" + string(scraperType) + "}"
+}
+
+// GetDelayedRetryQueue returns the Redis ZSET key a scraper type's
+// not-yet-due retries are scheduled in, keyed by due-time score.
+func GetDelayedRetryQueue(scraperType ScraperType) string {
+	return GetTaskQueue(scraperType) + ":delayed"
+}
+
+// GetDeadLetterQueue returns the Redis queue a scraper type's tasks are
+// moved to once they've exhausted MaxRetries, for manual inspection.
+func GetDeadLetterQueue(scraperType ScraperType) string {
+	return GetTaskQueue(scraperType) + ":dead"
 }
 
-// GetHealthKey returns the Redis key for a worker's health status
+// GetConsumerGroup returns the Redis Streams consumer group name for a
+// scraper type's task queue when running with QueueBackendStream.
+func GetConsumerGroup(scraperType ScraperType) string {
+	return GetTaskQueue(scraperType) + ":cg"
+}
+
+// GetPriorityQueue returns the Redis queue name for a specific scraper type
+// and priority band, e.g. "scraping:tasks:{indeed}:urgent".
+func GetPriorityQueue(scraperType ScraperType, priority TaskPriority) string {
+	return GetTaskQueue(scraperType) + ":" + string(priority)
+}
+
+// GetHeartbeatKey returns the Redis key a worker publishes its heartbeat to
+func GetHeartbeatKey(workerID string) string {
+	return ChannelWorkerHeartbeat + ":" + workerID
+}
+
+// GetHealthKey returns the Redis key for a worker's health status. The
+// scraper type is hash-tagged for the same Cluster-slot-colocation reason as
+// GetTaskQueue.
 func GetHealthKey(scraperType ScraperType, workerID string) string {
-	return ChannelHealthMonitor + ":" + string(scraperType) + ":" + workerID
+	return ChannelHealthMonitor + ":{" + string(scraperType) + "}:" + workerID
 }
 
 // GetHealthPattern returns the Redis pattern for all health keys of a scraper type
 func GetHealthPattern(scraperType ScraperType) string {
-	return ChannelHealthMonitor + ":" + string(scraperType) + ":*"
+	return ChannelHealthMonitor + ":{" + string(scraperType) + "}:*"
+}
+
+// GetPeerHealthChannel returns the Redis pub/sub channel workers of a
+// scraper type gossip their own HealthStatus on, so peers can reach quorum
+// about "is this worker broken" vs. "is the site down" (see
+// worker.HealthMonitor's peer-gossip subsystem) without a central
+// aggregator.
+func GetPeerHealthChannel(scraperType ScraperType) string {
+	return "health:peers:" + string(scraperType)
 }
 
 // Validate validates a scraping task
@@ -233,5 +446,6 @@ func NewHealthStatus(workerID string, scraperType ScraperType) *HealthStatus {
 		ProxySuccessRate:         100.0,
 		LastSuccessfulScrape:     time.Now().UTC().Format(time.RFC3339),
 		Timestamp:                time.Now().UTC().Format(time.RFC3339),
+		BrokerReachable:          true,
 	}
 }
\ No newline at end of file
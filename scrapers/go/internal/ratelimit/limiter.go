@@ -0,0 +1,332 @@
+// Package ratelimit implements an adaptive per-(site, proxy, region) request
+// limiter: a token bucket seeded from a static requests-per-minute ceiling,
+// whose refill rate is then adjusted AIMD-style by the response signals a
+// caller reports back through ReportOutcome - halved on 429/403/503 or a
+// detected CAPTCHA challenge, additively restored toward the ceiling on
+// sustained success - and gated by a resilience.CircuitBreaker that opens
+// for a cooldown window on the same failure signals. This replaces a static
+// RateLimitRPM ceiling with something that actually backs off when a site
+// pushes back, instead of only ever waiting at one fixed rate.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"github.com/jobspy/scrapers/internal/resilience"
+)
+
+// Tuple identifies one independently rate-limited target: a site reached
+// through a specific proxy from a specific region. Proxy and Region may be
+// left empty by a caller that doesn't distinguish by them - a backend that
+// only ever calls a single upstream URL (see jobspy_client) can key purely
+// on Site.
+type Tuple struct {
+	Site   string
+	Proxy  string
+	Region string
+}
+
+// Key returns Tuple's stable map and Redis key.
+func (t Tuple) Key() string {
+	return t.Site + "|" + t.Proxy + "|" + t.Region
+}
+
+// State is a bucket's persisted snapshot: everything needed to resume
+// without starting back at the ceiling - or worse, forgetting a halving
+// that a cooldown hasn't earned back yet.
+type State struct {
+	RefillPerSec float64   `json:"refill_per_sec"`
+	Tokens       float64   `json:"tokens"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Persister saves and loads a Tuple's State, e.g. in Redis keyed by
+// Tuple.Key(). redis.Client implements this directly via
+// SetLimiterState/GetLimiterState. A nil Persister disables persistence:
+// every bucket starts cold at its configured ceiling, which only matters
+// for the first request or two after a restart.
+type Persister interface {
+	SetLimiterState(tuple string, state State, ttl time.Duration) error
+	GetLimiterState(tuple string) (*State, bool, error)
+}
+
+const (
+	// aimdDecreaseFactor halves the refill rate on a failure signal.
+	aimdDecreaseFactor = 0.5
+
+	// aimdIncreaseStep is the fraction of the ceiling added back per
+	// increaseAfter consecutive successful reports, so one good response
+	// right after an outage doesn't immediately un-throttle a site that's
+	// still struggling.
+	aimdIncreaseStep = 0.1
+	increaseAfter    = 3
+
+	// minRefillPerSec floors the refill rate so a hammered tuple's bucket
+	// never fully stalls: it's still reachable, just slowly.
+	minRefillPerSec = 1.0 / 60.0 // one request per minute
+
+	// Circuit breaker thresholds guarding each tuple, matching the
+	// thresholds jobspy_client's own call-level breaker uses.
+	breakerFailureThreshold   = 5
+	breakerErrorRateThreshold = 0.5
+	breakerWindow             = time.Minute
+	breakerCooldown           = 30 * time.Second
+
+	persistTTL = 10 * time.Minute
+)
+
+var (
+	refillRateGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ratelimit_refill_rate_per_second",
+		Help: "Current AIMD-adjusted token bucket refill rate for a (site, proxy, region) tuple.",
+	}, []string{"site", "proxy", "region"})
+
+	breakerStateGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ratelimit_breaker_state",
+		Help: "Circuit breaker state for a (site, proxy, region) tuple: 0=closed, 1=open, 2=half-open.",
+	}, []string{"site", "proxy", "region"})
+)
+
+func init() {
+	prometheus.MustRegister(refillRateGauge, breakerStateGauge)
+}
+
+// bucket is one Tuple's token bucket state.
+type bucket struct {
+	mu              sync.Mutex
+	ceilingPerSec   float64
+	refillPerSec    float64
+	tokens          float64
+	lastRefill      time.Time
+	consecutiveGood int
+	blockedUntil    time.Time // set by ReportOutcome when RespectRetryAfter applies
+}
+
+// AdaptiveLimiter is a registry of per-Tuple token buckets, each guarded by
+// its own resilience.CircuitBreaker.
+type AdaptiveLimiter struct {
+	logger         *logrus.Logger
+	metricsEnabled bool
+	persister      Persister
+	breakers       *resilience.BreakerRegistry
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// NewAdaptiveLimiter builds an AdaptiveLimiter. persister may be nil to
+// disable state persistence across restarts.
+func NewAdaptiveLimiter(logger *logrus.Logger, metricsEnabled bool, persister Persister) *AdaptiveLimiter {
+	return &AdaptiveLimiter{
+		logger:         logger,
+		metricsEnabled: metricsEnabled,
+		persister:      persister,
+		breakers:       resilience.NewBreakerRegistry(breakerFailureThreshold, breakerErrorRateThreshold, breakerWindow, breakerCooldown),
+		buckets:        make(map[string]*bucket),
+	}
+}
+
+// getOrCreate returns tuple's bucket, seeding a new one from ceilingRPM (or
+// a previously persisted State, if one is found) the first time tuple is
+// seen.
+func (l *AdaptiveLimiter) getOrCreate(tuple Tuple, ceilingRPM int) *bucket {
+	key := tuple.Key()
+
+	l.mu.Lock()
+	b, ok := l.buckets[key]
+	if ok {
+		l.mu.Unlock()
+		return b
+	}
+
+	ceilingPerSec := float64(ceilingRPM) / 60.0
+	if ceilingPerSec <= 0 {
+		ceilingPerSec = 1
+	}
+	b = &bucket{
+		ceilingPerSec: ceilingPerSec,
+		refillPerSec:  ceilingPerSec,
+		tokens:        ceilingPerSec,
+		lastRefill:    time.Now(),
+	}
+	l.buckets[key] = b
+	l.mu.Unlock()
+
+	if l.persister != nil {
+		if state, found, err := l.persister.GetLimiterState(key); err != nil {
+			l.logger.WithError(err).WithField("tuple", key).Warn("Failed to load rate limiter state, starting cold")
+		} else if found {
+			b.mu.Lock()
+			b.refillPerSec = math.Min(state.RefillPerSec, b.ceilingPerSec)
+			b.tokens = math.Min(state.Tokens, b.ceilingPerSec)
+			b.lastRefill = state.UpdatedAt
+			b.mu.Unlock()
+		}
+	}
+
+	return b
+}
+
+// Allow reports whether tuple's circuit breaker currently permits a call,
+// independent of whether a token is available - a caller that wants to
+// short-circuit before waiting on the bucket at all can check this first.
+func (l *AdaptiveLimiter) Allow(tuple Tuple) bool {
+	return l.breakers.Get(tuple.Key()).Allow()
+}
+
+// Wait blocks until tuple has a token available - refilling it over time at
+// its current AIMD-adjusted rate - or ctx is cancelled, whichever comes
+// first. ceilingRPM seeds a never-before-seen tuple's bucket and is ignored
+// once the bucket already exists.
+func (l *AdaptiveLimiter) Wait(ctx context.Context, tuple Tuple, ceilingRPM int) error {
+	b := l.getOrCreate(tuple, ceilingRPM)
+
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		if now.Before(b.blockedUntil) {
+			wait := b.blockedUntil.Sub(now)
+			b.mu.Unlock()
+			if err := sleepCtx(ctx, wait); err != nil {
+				return err
+			}
+			continue
+		}
+
+		b.refill(now)
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			l.observeGauges(tuple, b)
+			return nil
+		}
+
+		deficit := 1 - b.tokens
+		wait := time.Duration(deficit/b.refillPerSec*float64(time.Second)) + time.Millisecond
+		b.mu.Unlock()
+
+		if err := sleepCtx(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+func (b *bucket) refill(now time.Time) {
+	elapsed := now.Sub(b.lastRefill).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	b.tokens += elapsed * b.refillPerSec
+	// Hold at least one whole token, or a ceiling under 60 RPM could never
+	// satisfy Wait.
+	capacity := math.Max(b.ceilingPerSec, 1)
+	if b.tokens > capacity {
+		b.tokens = capacity
+	}
+	b.lastRefill = now
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ReportOutcome feeds one HTTP response's signal back into tuple's bucket
+// and circuit breaker: a 429/403/503 or a detected CAPTCHA challenge halves
+// the refill rate (AIMD decrease) and records a breaker failure; a 2xx
+// records a breaker success and, once increaseAfter of them have landed in
+// a row, additively nudges the refill rate back toward the ceiling. If
+// respectRetryAfter is true and retryAfter is positive, the bucket is
+// blocked for exactly that long instead of relying on the AIMD-computed
+// rate alone.
+func (l *AdaptiveLimiter) ReportOutcome(tuple Tuple, statusCode int, captchaDetected bool, respectRetryAfter bool, retryAfter time.Duration) {
+	key := tuple.Key()
+	breaker := l.breakers.Get(key)
+	b := l.getOrCreate(tuple, 0)
+
+	failed := captchaDetected ||
+		statusCode == http.StatusTooManyRequests ||
+		statusCode == http.StatusForbidden ||
+		statusCode == http.StatusServiceUnavailable
+	succeeded := statusCode >= 200 && statusCode < 300
+
+	b.mu.Lock()
+	switch {
+	case failed:
+		breaker.RecordFailure()
+		b.refillPerSec = math.Max(b.refillPerSec*aimdDecreaseFactor, minRefillPerSec)
+		b.consecutiveGood = 0
+		if respectRetryAfter && retryAfter > 0 {
+			b.blockedUntil = time.Now().Add(retryAfter)
+		}
+	case succeeded:
+		breaker.RecordSuccess()
+		b.consecutiveGood++
+		if b.consecutiveGood >= increaseAfter {
+			b.consecutiveGood = 0
+			b.refillPerSec = math.Min(b.refillPerSec+b.ceilingPerSec*aimdIncreaseStep, b.ceilingPerSec)
+		}
+	}
+	snapshot := State{RefillPerSec: b.refillPerSec, Tokens: b.tokens, UpdatedAt: time.Now()}
+	b.mu.Unlock()
+
+	l.observeGauges(tuple, b)
+
+	if l.persister != nil {
+		if err := l.persister.SetLimiterState(key, snapshot, persistTTL); err != nil {
+			l.logger.WithError(err).WithField("tuple", key).Warn("Failed to persist rate limiter state")
+		}
+	}
+}
+
+func (l *AdaptiveLimiter) observeGauges(tuple Tuple, b *bucket) {
+	if !l.metricsEnabled {
+		return
+	}
+	b.mu.Lock()
+	refill := b.refillPerSec
+	b.mu.Unlock()
+
+	refillRateGauge.WithLabelValues(tuple.Site, tuple.Proxy, tuple.Region).Set(refill)
+	breakerStateGauge.WithLabelValues(tuple.Site, tuple.Proxy, tuple.Region).Set(float64(l.breakers.Get(tuple.Key()).State()))
+}
+
+// ParseRetryAfter reads a Retry-After response header, supporting both the
+// delay-in-seconds and HTTP-date forms RFC 9110 allows. Returns false if
+// header is empty or unparsable as either form.
+func ParseRetryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if when, err := http.ParseTime(header); err == nil {
+		delay := time.Until(when)
+		if delay < 0 {
+			return 0, true
+		}
+		return delay, true
+	}
+	return 0, false
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/go-redis/redis/v8"
@@ -12,15 +13,35 @@ import (
 
 // Client wraps Redis client with production-ready features
 type Client struct {
-	client *redis.Client
+	client redis.UniversalClient
 	ctx    context.Context
 	logger *logrus.Logger
 	config *Config
+
+	// batcher backs PushTaskBatched; nil unless config.PipePeriod > 0. Held
+	// behind a pointer (rather than inline fields) so Client itself stays a
+	// plain copyable value, as WithContext relies on.
+	batcher *pipelineBatcher
+}
+
+// pipelineBatcher holds the long-lived pipeline PushTaskBatched writes onto
+// and the goroutine that periodically flushes it.
+type pipelineBatcher struct {
+	pipe   redis.Pipeliner
+	lock   sync.Mutex
+	stopCh chan struct{}
+	doneCh chan struct{}
 }
 
 // Config holds Redis client configuration
 type Config struct {
-	URL              string
+	URL string
+	// Addrs, when non-empty, switches NewClient to Redis Cluster (or, with a
+	// MasterName, Sentinel) mode via redis.NewUniversalClient - one addr per
+	// shard/sentinel node. Leave empty for a single-node deployment; URL is
+	// then used as the sole address.
+	Addrs            []string
+	MasterName       string
 	Password         string
 	DB               int
 	PoolSize         int
@@ -35,6 +56,18 @@ type Config struct {
 	MaxRetries       int
 	MinRetryBackoff  time.Duration
 	MaxRetryBackoff  time.Duration
+
+	// QueueBackend selects between the legacy LPUSH/BRPOP list queue and a
+	// Redis Streams + consumer group backend that survives a worker crash
+	// mid-scrape. Defaults to QueueBackendList so existing deployments keep
+	// working unchanged.
+	QueueBackend QueueBackend
+
+	// PipePeriod, when non-zero, switches PushTaskBatched to batch LPUSHes
+	// onto a long-lived pipeline flushed every PipePeriod instead of issuing
+	// a round-trip per task. Zero disables batching (PushTaskBatched falls
+	// back to the same per-call behavior as PushTask).
+	PipePeriod time.Duration
 }
 
 // DefaultConfig returns Redis config with production-ready defaults
@@ -54,44 +87,53 @@ func DefaultConfig() *Config {
 		MaxRetries:       3,
 		MinRetryBackoff:  500 * time.Millisecond,
 		MaxRetryBackoff:  2 * time.Second,
+		QueueBackend:     QueueBackendList,
 	}
 }
 
-// NewClient creates a new production-ready Redis client
+// NewClient creates a new production-ready Redis client. It uses
+// redis.NewUniversalClient so single-node, Cluster (config.Addrs has more
+// than one entry), and Sentinel (config.MasterName set) deployments all go
+// through one code path instead of NewClient hardcoding *redis.Client.
 func NewClient(config *Config, logger *logrus.Logger) (*Client, error) {
 	if config == nil {
 		config = DefaultConfig()
 	}
 
-	// Parse Redis URL
-	opts, err := redis.ParseURL(config.URL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse Redis URL: %w", err)
-	}
-
-	// Apply custom configuration
-	opts.Password = config.Password
-	opts.DB = config.DB
-	opts.PoolSize = config.PoolSize
-	opts.MinIdleConns = config.MinIdleConns
-	opts.MaxConnAge = config.MaxConnAge
-	opts.PoolTimeout = config.PoolTimeout
-	opts.IdleTimeout = config.IdleTimeout
-	opts.IdleCheckFreq = config.IdleCheckFreq
-	opts.ReadTimeout = config.ReadTimeout
-	opts.WriteTimeout = config.WriteTimeout
-	opts.DialTimeout = config.DialTimeout
-	opts.MaxRetries = config.MaxRetries
-	opts.MinRetryBackoff = config.MinRetryBackoff
-	opts.MaxRetryBackoff = config.MaxRetryBackoff
-
-	// Enable connection pooling and health checks
-	opts.OnConnect = func(ctx context.Context, cn *redis.Conn) error {
-		logger.Debug("New Redis connection established")
-		return nil
+	addrs := config.Addrs
+	if len(addrs) == 0 {
+		// Single-node deployment: derive the one address from URL.
+		opts, err := redis.ParseURL(config.URL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse Redis URL: %w", err)
+		}
+		addrs = []string{opts.Addr}
 	}
 
-	client := redis.NewClient(opts)
+	universalOpts := &redis.UniversalOptions{
+		Addrs:              addrs,
+		MasterName:         config.MasterName,
+		Password:           config.Password,
+		DB:                 config.DB,
+		PoolSize:           config.PoolSize,
+		MinIdleConns:       config.MinIdleConns,
+		MaxConnAge:         config.MaxConnAge,
+		PoolTimeout:        config.PoolTimeout,
+		IdleTimeout:        config.IdleTimeout,
+		IdleCheckFrequency: config.IdleCheckFreq,
+		ReadTimeout:        config.ReadTimeout,
+		WriteTimeout:       config.WriteTimeout,
+		DialTimeout:        config.DialTimeout,
+		MaxRetries:         config.MaxRetries,
+		MinRetryBackoff:    config.MinRetryBackoff,
+		MaxRetryBackoff:    config.MaxRetryBackoff,
+		OnConnect: func(ctx context.Context, cn *redis.Conn) error {
+			logger.Debug("New Redis connection established")
+			return nil
+		},
+	}
+
+	client := redis.NewUniversalClient(universalOpts)
 
 	// Test connection
 	ctx := context.Background()
@@ -100,21 +142,38 @@ func NewClient(config *Config, logger *logrus.Logger) (*Client, error) {
 	}
 
 	logger.WithFields(logrus.Fields{
-		"url":       config.URL,
+		"addrs":     addrs,
 		"db":        config.DB,
 		"pool_size": config.PoolSize,
 	}).Info("Redis client connected successfully")
 
-	return &Client{
+	redisClient := &Client{
 		client: client,
 		ctx:    context.Background(),
 		logger: logger,
 		config: config,
-	}, nil
+	}
+
+	if config.PipePeriod > 0 {
+		redisClient.batcher = &pipelineBatcher{
+			pipe:   client.Pipeline(),
+			stopCh: make(chan struct{}),
+			doneCh: make(chan struct{}),
+		}
+		go redisClient.pipeFlushLoop()
+	}
+
+	return redisClient, nil
 }
 
-// Close closes the Redis connection
+// Close closes the Redis connection, flushing any still-batched
+// PushTaskBatched writes first.
 func (c *Client) Close() error {
+	if c.batcher != nil {
+		close(c.batcher.stopCh)
+		<-c.batcher.doneCh
+		c.flushPipeline()
+	}
 	return c.client.Close()
 }
 
@@ -130,6 +189,17 @@ func (c *Client) Health() error {
 	return nil
 }
 
+// Ping checks Redis connectivity using the caller's context instead of
+// Health's fixed 5-second timeout, so a recurring prober (see
+// worker.HealthMonitor's broker connectivity check) can bound it against
+// its own tick interval or shutdown context instead.
+func (c *Client) Ping(ctx context.Context) error {
+	if err := c.client.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("Redis ping failed: %w", err)
+	}
+	return nil
+}
+
 // GetStats returns Redis connection pool statistics
 func (c *Client) GetStats() *redis.PoolStats {
 	return c.client.PoolStats()
@@ -159,6 +229,57 @@ func (c *Client) PushTask(queue string, task interface{}) error {
 	return nil
 }
 
+// PushTaskBatched enqueues task the same way PushTask does, but when
+// config.PipePeriod is set it queues the LPUSH on a shared, long-lived
+// pipeline instead of round-tripping immediately; the pipeline is flushed
+// every PipePeriod (and on Close). This lets a scheduler re-hydrating a
+// whole search plan enqueue thousands of tasks per second without paying a
+// round-trip per task. With PipePeriod unset it's identical to PushTask.
+func (c *Client) PushTaskBatched(queue string, task interface{}) error {
+	if c.batcher == nil {
+		return c.PushTask(queue, task)
+	}
+
+	data, err := json.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task: %w", err)
+	}
+
+	c.batcher.lock.Lock()
+	c.batcher.pipe.LPush(c.ctx, queue, data)
+	c.batcher.lock.Unlock()
+
+	return nil
+}
+
+func (c *Client) pipeFlushLoop() {
+	defer close(c.batcher.doneCh)
+
+	ticker := time.NewTicker(c.config.PipePeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.flushPipeline()
+		case <-c.batcher.stopCh:
+			return
+		}
+	}
+}
+
+func (c *Client) flushPipeline() {
+	c.batcher.lock.Lock()
+	defer c.batcher.lock.Unlock()
+
+	ctx, cancel := context.WithTimeout(c.ctx, c.config.WriteTimeout)
+	defer cancel()
+
+	if _, err := c.batcher.pipe.Exec(ctx); err != nil && err != redis.Nil {
+		c.logger.WithError(err).Error("Failed to flush batched task pipeline")
+	}
+}
+
 // PopTask pops a task from a queue with timeout (blocking right pop)
 func (c *Client) PopTask(queue string, timeout time.Duration, result interface{}) (bool, error) {
 	ctx, cancel := context.WithTimeout(c.ctx, timeout+5*time.Second)
@@ -190,6 +311,107 @@ func (c *Client) PopTask(queue string, timeout time.Duration, result interface{}
 	return true, nil
 }
 
+// PopTaskMulti pops a task from one of several weighted priority queues.
+// Queues are tried in descending order of their current credit balance
+// (queues[i] paired with weights[i]); each is probed with a short
+// non-blocking attempt before falling through to the next. If none of the
+// queues have work, it blocks on all of them at once (one BRPOP, keys in
+// the same weighted order) for the remainder of timeout so the poller isn't
+// a pure busy-loop. It returns the
+// queue name the task was popped from so callers can track per-queue
+// throughput for fairness metrics.
+func (c *Client) PopTaskMulti(queues []string, weights []int, timeout time.Duration, result interface{}) (bool, string, error) {
+	if len(queues) == 0 {
+		return false, "", fmt.Errorf("no queues provided")
+	}
+	if len(weights) != len(queues) {
+		return false, "", fmt.Errorf("weights must match queues 1:1")
+	}
+
+	order := weightedOrder(queues, weights)
+
+	probeTimeout := 50 * time.Millisecond
+	for _, queue := range order {
+		ctx, cancel := context.WithTimeout(c.ctx, probeTimeout+5*time.Second)
+		data, err := c.client.BRPop(ctx, probeTimeout, queue).Result()
+		cancel()
+
+		if err != nil {
+			if err == redis.Nil {
+				continue
+			}
+			return false, "", fmt.Errorf("failed to pop task from queue %s: %w", queue, err)
+		}
+		if len(data) < 2 {
+			return false, "", fmt.Errorf("invalid response from BRPop")
+		}
+		if err := json.Unmarshal([]byte(data[1]), result); err != nil {
+			return false, "", fmt.Errorf("failed to unmarshal task: %w", err)
+		}
+		return true, queue, nil
+	}
+
+	// Nothing ready on a quick pass; block on every queue for the remaining
+	// time budget so callers still get blocking semantics.
+	remaining := timeout - time.Duration(len(order))*probeTimeout
+	if remaining <= 0 {
+		return false, "", nil
+	}
+
+	ctx, cancel := context.WithTimeout(c.ctx, remaining+5*time.Second)
+	defer cancel()
+
+	data, err := c.client.BRPop(ctx, remaining, order...).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return false, "", nil
+		}
+		return false, "", fmt.Errorf("failed to pop task from queues %v: %w", queues, err)
+	}
+	if len(data) < 2 {
+		return false, "", fmt.Errorf("invalid response from BRPop")
+	}
+	if err := json.Unmarshal([]byte(data[1]), result); err != nil {
+		return false, "", fmt.Errorf("failed to unmarshal task: %w", err)
+	}
+	return true, data[0], nil
+}
+
+// weightedOrder returns queues sorted by descending weight. Ties keep their
+// original relative order so the priority list (urgent, normal, backfill)
+// still determines the probe sequence among equally-weighted queues.
+func weightedOrder(queues []string, weights []int) []string {
+	order := make([]string, len(queues))
+	copy(order, queues)
+
+	for i := 1; i < len(order); i++ {
+		for j := i; j > 0 && weights[j] > weights[j-1]; j-- {
+			order[j], order[j-1] = order[j-1], order[j]
+			weights[j], weights[j-1] = weights[j-1], weights[j]
+		}
+	}
+	return order
+}
+
+// --- Worker Discovery ---
+
+// SetHeartbeat publishes a worker heartbeat with a TTL slightly longer than
+// the expected heartbeat interval so a dead worker disappears quickly.
+func (c *Client) SetHeartbeat(key string, heartbeat interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(heartbeat)
+	if err != nil {
+		return fmt.Errorf("failed to marshal heartbeat: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(c.ctx, c.config.WriteTimeout)
+	defer cancel()
+
+	if err := c.client.Set(ctx, key, data, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to set heartbeat: %w", err)
+	}
+	return nil
+}
+
 // GetQueueLength returns the number of items in a queue
 func (c *Client) GetQueueLength(queue string) (int64, error) {
 	ctx, cancel := context.WithTimeout(c.ctx, c.config.ReadTimeout)
@@ -279,12 +501,64 @@ func (c *Client) GetHealth(key string, result interface{}) (bool, error) {
 	return true, nil
 }
 
-// GetAllHealthKeys gets all health monitoring keys matching a pattern
-func (c *Client) GetAllHealthKeys(pattern string) ([]string, error) {
-	ctx, cancel := context.WithTimeout(c.ctx, c.config.ReadTimeout)
-	defer cancel()
+// defaultScanPageSize is the COUNT hint passed to each SCAN call made by
+// ScanKeys/GetAllHealthKeys when the caller doesn't specify one.
+const defaultScanPageSize = 200
+
+// ScanKeys walks every key matching pattern using cursor-based SCAN rather
+// than KEYS, so a large keyspace doesn't block the server with one O(N)
+// command. On a Redis Cluster, c.client is a *redis.ClusterClient and SCAN
+// only ever sees one shard per call, so ScanKeys fans out across every
+// master node instead; on a single-node or Sentinel deployment it just
+// scans the one client. fn is invoked once per page of keys (size up to
+// pageSize); returning an error from fn stops the walk early.
+func (c *Client) ScanKeys(pattern string, pageSize int64, fn func(keys []string) error) error {
+	if pageSize <= 0 {
+		pageSize = defaultScanPageSize
+	}
+
+	if cluster, ok := c.client.(*redis.ClusterClient); ok {
+		return cluster.ForEachMaster(c.ctx, func(ctx context.Context, master *redis.Client) error {
+			return scanNode(ctx, master, pattern, pageSize, fn)
+		})
+	}
 
-	keys, err := c.client.Keys(ctx, pattern).Result()
+	return scanNode(c.ctx, c.client, pattern, pageSize, fn)
+}
+
+// scanNode runs the cursor-based SCAN loop against a single node.
+func scanNode(ctx context.Context, node redis.Cmdable, pattern string, pageSize int64, fn func(keys []string) error) error {
+	var cursor uint64
+	for {
+		scanCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		keys, next, err := node.Scan(scanCtx, cursor, pattern, pageSize).Result()
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to scan keys matching %s: %w", pattern, err)
+		}
+
+		if len(keys) > 0 {
+			if err := fn(keys); err != nil {
+				return err
+			}
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return nil
+		}
+	}
+}
+
+// GetAllHealthKeys gets all health monitoring keys matching a pattern. It
+// scans incrementally via ScanKeys rather than issuing a single blocking
+// KEYS command.
+func (c *Client) GetAllHealthKeys(pattern string) ([]string, error) {
+	var keys []string
+	err := c.ScanKeys(pattern, defaultScanPageSize, func(page []string) error {
+		keys = append(keys, page...)
+		return nil
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get health keys: %w", err)
 	}
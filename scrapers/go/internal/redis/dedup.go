@@ -0,0 +1,147 @@
+package redis
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jobspy/scrapers/internal/protocol"
+)
+
+// PushTaskUnique pushes task onto queue the same way PushTask does, but only
+// if an equivalent task (same scraper type and search parameters) hasn't
+// already been enqueued within the last dedupWindow - protecting against a
+// scheduler restart replaying a search plan it already submitted. It reports
+// whether the task was actually enqueued.
+func (c *Client) PushTaskUnique(queue string, task *protocol.ScrapingTask, dedupWindow time.Duration) (bool, error) {
+	key := "dedup:" + queue + ":" + taskDedupHash(task)
+
+	ctx, cancel := context.WithTimeout(c.ctx, c.config.WriteTimeout)
+	acquired, err := c.client.SetNX(ctx, key, task.TaskID, dedupWindow).Result()
+	cancel()
+	if err != nil {
+		return false, fmt.Errorf("failed to check dedup key for task %s: %w", task.TaskID, err)
+	}
+	if !acquired {
+		return false, nil
+	}
+
+	if err := c.PushTask(queue, task); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// taskDedupHash computes a stable hash over the parameters that make two
+// ScrapingTasks equivalent search requests, so a replayed task with a fresh
+// TaskID still collides with the original in PushTaskUnique.
+func taskDedupHash(task *protocol.ScrapingTask) string {
+	p := task.Params
+	fields := []string{
+		string(task.ScraperType),
+		p.SearchTerm,
+		p.Location,
+		stringPtrOr(p.JobType, ""),
+		strconv.FormatBool(boolPtrOr(p.IsRemote, false)),
+		intPtrOr(p.SalaryMin, 0),
+		intPtrOr(p.SalaryMax, 0),
+		strconv.Itoa(p.PageLimit),
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(fields, "|")))
+	return hex.EncodeToString(sum[:])
+}
+
+func stringPtrOr(s *string, fallback string) string {
+	if s == nil {
+		return fallback
+	}
+	return *s
+}
+
+func boolPtrOr(b *bool, fallback bool) bool {
+	if b == nil {
+		return fallback
+	}
+	return *b
+}
+
+func intPtrOr(i *int, fallback int) string {
+	if i == nil {
+		return strconv.Itoa(fallback)
+	}
+	return strconv.Itoa(*i)
+}
+
+// RecordJobHashes adds hashes to scraperType's seen-jobs Set with a sliding
+// expiry of ttl, so FilterNewJobHashes can later recognize them as
+// already-published duplicates.
+func (c *Client) RecordJobHashes(scraperType protocol.ScraperType, hashes []string, ttl time.Duration) error {
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	key := seenJobHashesKey(scraperType)
+	members := make([]interface{}, len(hashes))
+	for i, h := range hashes {
+		members[i] = h
+	}
+
+	ctx, cancel := context.WithTimeout(c.ctx, c.config.WriteTimeout)
+	defer cancel()
+
+	pipe := c.client.TxPipeline()
+	pipe.SAdd(ctx, key, members...)
+	pipe.Expire(ctx, key, ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to record job hashes for %s: %w", scraperType, err)
+	}
+
+	return nil
+}
+
+// FilterNewJobHashes returns the subset of hashes not already recorded for
+// scraperType, so a worker can drop JobData entries it has already
+// published in the last N hours before publishing a ScrapingResult.
+func (c *Client) FilterNewJobHashes(scraperType protocol.ScraperType, hashes []string) ([]string, error) {
+	if len(hashes) == 0 {
+		return nil, nil
+	}
+
+	key := seenJobHashesKey(scraperType)
+
+	ctx, cancel := context.WithTimeout(c.ctx, c.config.ReadTimeout)
+	defer cancel()
+
+	seen, err := c.client.SMIsMember(ctx, key, toInterfaceSlice(hashes)...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check seen job hashes for %s: %w", scraperType, err)
+	}
+
+	fresh := make([]string, 0, len(hashes))
+	for i, hash := range hashes {
+		if i < len(seen) && !seen[i] {
+			fresh = append(fresh, hash)
+		}
+	}
+	return fresh, nil
+}
+
+func toInterfaceSlice(hashes []string) []interface{} {
+	members := make([]interface{}, len(hashes))
+	for i, h := range hashes {
+		members[i] = h
+	}
+	return members
+}
+
+// seenJobHashesKey is the per-scraper Set RecordJobHashes/FilterNewJobHashes
+// share. Hash-tagged for the same Cluster-slot-colocation reason as
+// protocol.GetTaskQueue.
+func seenJobHashesKey(scraperType protocol.ScraperType) string {
+	return "scraping:jobhashes:{" + string(scraperType) + "}"
+}
@@ -0,0 +1,131 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// LeaderElector implements leader election via a Redis SET NX PX lease.
+// Exactly one process holding the lease at a time is considered the leader;
+// the lease is renewed on a fraction of its TTL so a slow renewal doesn't
+// flap leadership, and released on Resign so a graceful shutdown hands off
+// immediately instead of waiting out the TTL.
+type LeaderElector struct {
+	client   *Client
+	logger   *logrus.Logger
+	key      string
+	id       string
+	ttl      time.Duration
+	isLeader bool
+}
+
+// NewLeaderElector creates a new leader elector campaigning on key.
+func NewLeaderElector(client *Client, key string, ttl time.Duration, logger *logrus.Logger) *LeaderElector {
+	return &LeaderElector{
+		client: client,
+		logger: logger,
+		key:    key,
+		id:     uuid.New().String(),
+		ttl:    ttl,
+	}
+}
+
+// ID returns this elector's campaign identity.
+func (le *LeaderElector) ID() string {
+	return le.id
+}
+
+// IsLeader returns whether this elector currently holds the lease.
+func (le *LeaderElector) IsLeader() bool {
+	return le.isLeader
+}
+
+// Campaign runs the election loop until ctx is cancelled, calling onElected
+// when leadership is acquired and onDemoted when it is lost. It renews the
+// lease every ttl/3 and attempts to acquire it at the same cadence when not
+// already leading.
+func (le *LeaderElector) Campaign(ctx context.Context, onElected func(), onDemoted func()) {
+	renewInterval := le.ttl / 3
+	if renewInterval <= 0 {
+		renewInterval = time.Second
+	}
+
+	ticker := time.NewTicker(renewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if le.isLeader {
+				le.Resign(context.Background())
+			}
+			return
+		case <-ticker.C:
+			le.tick(onElected, onDemoted)
+		}
+	}
+}
+
+func (le *LeaderElector) tick(onElected func(), onDemoted func()) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	acquired, err := le.client.client.SetNX(ctx, le.key, le.id, le.ttl).Result()
+	if err != nil {
+		le.logger.WithError(err).Warn("Leader election probe failed")
+		if le.isLeader {
+			le.isLeader = false
+			onDemoted()
+		}
+		return
+	}
+
+	if acquired {
+		if !le.isLeader {
+			le.isLeader = true
+			le.logger.WithField("leader_id", le.id).Info("Acquired scheduler leadership")
+			onElected()
+		}
+		return
+	}
+
+	// Key already exists: either we hold it (renew) or someone else does.
+	holder, err := le.client.client.Get(ctx, le.key).Result()
+	if err != nil {
+		le.logger.WithError(err).Warn("Failed to read leader lease holder")
+		if le.isLeader {
+			le.isLeader = false
+			onDemoted()
+		}
+		return
+	}
+
+	if holder == le.id {
+		if err := le.client.client.Expire(ctx, le.key, le.ttl).Err(); err != nil {
+			le.logger.WithError(err).Warn("Failed to renew leader lease")
+		}
+		return
+	}
+
+	if le.isLeader {
+		le.isLeader = false
+		le.logger.WithField("new_leader", holder).Info("Lost scheduler leadership")
+		onDemoted()
+	}
+}
+
+// Resign releases the lease immediately if this elector currently holds it.
+func (le *LeaderElector) Resign(ctx context.Context) {
+	if !le.isLeader {
+		return
+	}
+
+	holder, err := le.client.client.Get(ctx, le.key).Result()
+	if err == nil && holder == le.id {
+		le.client.client.Del(ctx, le.key)
+	}
+	le.isLeader = false
+}
@@ -0,0 +1,151 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/sirupsen/logrus"
+
+	"github.com/jobspy/scrapers/internal/protocol"
+)
+
+const periodicClaimBatchSize = 20
+
+// PeriodicDispatcher polls the periodic-job schedule ZSET and, for each due
+// job, enqueues a child scraping task and reschedules the job's next
+// firing according to its cron expression. A paused or stopped job is
+// still claimed when due - so it's always rescheduled off the real cron
+// sequence - but skipped without firing, so pausing never produces a
+// backlog that fires all at once on resume.
+type PeriodicDispatcher struct {
+	redisClient *Client
+	logger      *logrus.Logger
+	backend     QueueBackend
+	interval    time.Duration
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+// NewPeriodicDispatcher creates a dispatcher polling every pollInterval.
+// backend determines whether a fired job's task is enqueued onto the list,
+// stream or priority live queue.
+func NewPeriodicDispatcher(redisClient *Client, logger *logrus.Logger, backend QueueBackend, pollInterval time.Duration) *PeriodicDispatcher {
+	return &PeriodicDispatcher{
+		redisClient: redisClient,
+		logger:      logger,
+		backend:     backend,
+		interval:    pollInterval,
+		stopCh:      make(chan struct{}),
+		doneCh:      make(chan struct{}),
+	}
+}
+
+// Start runs the poll loop until ctx is cancelled or Stop is called.
+func (d *PeriodicDispatcher) Start(ctx context.Context) {
+	go d.run(ctx)
+}
+
+// Stop signals the poll loop to exit and waits for it to do so.
+func (d *PeriodicDispatcher) Stop() {
+	close(d.stopCh)
+	<-d.doneCh
+}
+
+func (d *PeriodicDispatcher) run(ctx context.Context) {
+	defer close(d.doneCh)
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			d.dispatchDue()
+		case <-d.stopCh:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (d *PeriodicDispatcher) dispatchDue() {
+	now := time.Now().UTC()
+
+	ids, err := d.redisClient.claimDuePeriodicJobs(now, periodicClaimBatchSize)
+	if err != nil {
+		d.logger.WithError(err).Warn("Periodic dispatcher failed to claim due jobs")
+		return
+	}
+
+	for _, id := range ids {
+		d.fire(id, now)
+	}
+}
+
+func (d *PeriodicDispatcher) fire(jobID string, now time.Time) {
+	logger := d.logger.WithField("periodic_job_id", jobID)
+
+	job, err := d.redisClient.GetPeriodicJob(jobID)
+	if err != nil {
+		logger.WithError(err).Error("Periodic dispatcher failed to load due job")
+		return
+	}
+	if job == nil {
+		// Deleted after being claimed; nothing left to fire or reschedule.
+		return
+	}
+
+	schedule, err := cron.ParseStandard(job.CronExpr)
+	if err != nil {
+		logger.WithError(err).Error("Periodic dispatcher found an unparsable cron expression, not rescheduling")
+		return
+	}
+	if err := d.redisClient.scheduleNextFire(job.ID, schedule.Next(now)); err != nil {
+		logger.WithError(err).Error("Periodic dispatcher failed to reschedule job")
+	}
+
+	if job.Status != PeriodicJobActive {
+		return
+	}
+
+	executionID := ExecutionID(job.ID, now)
+	task := &protocol.ScrapingTask{
+		TaskID:      executionID,
+		ScraperType: job.ScraperType,
+		Params:      job.Params,
+		CreatedAt:   now.Format(time.RFC3339),
+	}
+
+	exec := &PeriodicExecution{
+		ExecutionID:   executionID,
+		PeriodicJobID: job.ID,
+		Status:        protocol.TaskStatusPending,
+		StartedAt:     now.Format(time.RFC3339),
+	}
+	if err := d.redisClient.AppendExecution(job.ID, exec); err != nil {
+		logger.WithError(err).Error("Periodic dispatcher failed to record execution")
+	}
+
+	queue := protocol.GetTaskQueue(job.ScraperType)
+	switch d.backend {
+	case QueueBackendStream:
+		if _, err := d.redisClient.PushTaskStream(queue, task); err != nil {
+			logger.WithError(err).Error("Periodic dispatcher failed to enqueue due job onto stream")
+		}
+	case QueueBackendPriority:
+		if err := d.redisClient.PushPrioritizedTask(queue, task); err != nil {
+			logger.WithError(err).Error("Periodic dispatcher failed to enqueue due job onto priority queue")
+		}
+	default:
+		// dispatchDue can fire several jobs from the same poll tick;
+		// PushTaskBatched lets them share one pipelined round trip instead
+		// of one LPUSH each (falls back to PushTask's per-call behavior
+		// when the client has no PipePeriod configured).
+		if err := d.redisClient.PushTaskBatched(queue, task); err != nil {
+			logger.WithError(err).Error("Periodic dispatcher failed to enqueue due job onto queue")
+		}
+	}
+}
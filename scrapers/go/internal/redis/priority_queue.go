@@ -0,0 +1,169 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+
+	"github.com/jobspy/scrapers/internal/protocol"
+)
+
+// priorityScoreEpoch anchors the timestamp folded into a priority queue
+// score. Using an epoch close to "now" (rather than the Unix epoch) keeps
+// the microsecond component small enough that priorityBand*priorityScale
+// never collides with it, while still leaving decades of headroom.
+var priorityScoreEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// priorityScale separates the priority band from the timestamp folded into
+// the low bits of a ZSET score. It must exceed the largest possible
+// (now - priorityScoreEpoch) in microseconds for the life of a deployment;
+// 1e15 covers roughly 31 years at microsecond resolution.
+const priorityScale = 1e15
+
+// maxTaskPriority bounds protocol.ScrapingTask.Priority for score encoding.
+// Values outside [0, maxTaskPriority] are clamped rather than rejected, so
+// a caller-supplied priority can never escape its intended band.
+const maxTaskPriority = 9
+
+// priorityPopScript atomically pops the lowest-scoring (highest-priority,
+// then oldest) member of a priority ZSET, mirroring ZPOPMIN but letting us
+// ship it as one round trip.
+var priorityPopScript = goredis.NewScript(`
+local result = redis.call('ZPOPMIN', KEYS[1])
+if #result == 0 then
+	return false
+end
+return result
+`)
+
+// priorityScore computes a ZSET score where a higher protocol priority
+// always sorts before a lower one, and - within the same priority - an
+// older task (smaller createdAt) always sorts before a newer one.
+//
+// Layout: score = (maxTaskPriority - priority) * priorityScale + relativeMicros
+//
+// Inverting the priority into the high-order band is what makes ZPOPMIN
+// (smallest score first) return the highest-priority task first; folding
+// the creation timestamp into the remaining low-order bits of that same
+// band is what gives FIFO ordering among same-priority tasks, since within
+// a band the score is monotonic in time.
+func priorityScore(priority int, createdAt time.Time) float64 {
+	if priority < 0 {
+		priority = 0
+	}
+	if priority > maxTaskPriority {
+		priority = maxTaskPriority
+	}
+
+	relativeMicros := createdAt.Sub(priorityScoreEpoch).Microseconds()
+	if relativeMicros < 0 {
+		relativeMicros = 0
+	}
+
+	band := float64(maxTaskPriority - priority)
+	return band*priorityScale + float64(relativeMicros)
+}
+
+// notifyKey is the list PopPrioritizedTask blocks on so it isn't a pure
+// busy-poll loop; PushPrioritizedTask pushes a placeholder onto it whenever
+// it adds work. The placeholder's value is never inspected.
+func notifyKey(queue string) string {
+	return queue + ":notify"
+}
+
+// PushPrioritizedTask enqueues task onto queue's priority ZSET, scored so
+// PopPrioritizedTask always returns the highest-priority, then oldest,
+// pending task.
+func (c *Client) PushPrioritizedTask(queue string, task *protocol.ScrapingTask) error {
+	data, err := json.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task: %w", err)
+	}
+
+	createdAt, err := time.Parse(time.RFC3339, task.CreatedAt)
+	if err != nil {
+		createdAt = time.Now().UTC()
+	}
+	score := priorityScore(task.Priority, createdAt)
+
+	ctx, cancel := context.WithTimeout(c.ctx, c.config.WriteTimeout)
+	defer cancel()
+
+	pipe := c.client.TxPipeline()
+	pipe.ZAdd(ctx, queue, &goredis.Z{Score: score, Member: data})
+	pipe.LPush(ctx, notifyKey(queue), "1")
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to push prioritized task to %s: %w", queue, err)
+	}
+
+	return nil
+}
+
+// PopPrioritizedTask pops the highest-priority (then oldest) task from
+// queue's priority ZSET, blocking up to timeout for one to appear. It
+// alternates an atomic ZPOPMIN attempt with a short BLPOP on the queue's
+// notification list, since BZPOPMIN isn't available on every deployment
+// this client targets.
+func (c *Client) PopPrioritizedTask(queue string, timeout time.Duration, result interface{}) (bool, error) {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		popped, err := c.tryPopPriority(queue, result)
+		if err != nil {
+			return false, err
+		}
+		if popped {
+			return true, nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return false, nil
+		}
+
+		waitFor := remaining
+		if waitFor > time.Second {
+			waitFor = time.Second
+		}
+
+		ctx, cancel := context.WithTimeout(c.ctx, waitFor+5*time.Second)
+		_, err = c.client.BLPop(ctx, waitFor, notifyKey(queue)).Result()
+		cancel()
+		if err != nil && err != goredis.Nil {
+			return false, fmt.Errorf("failed waiting on priority queue notification for %s: %w", queue, err)
+		}
+	}
+}
+
+func (c *Client) tryPopPriority(queue string, result interface{}) (bool, error) {
+	ctx, cancel := context.WithTimeout(c.ctx, c.config.ReadTimeout)
+	defer cancel()
+
+	raw, err := priorityPopScript.Run(ctx, c.client, []string{queue}).Result()
+	if err != nil {
+		if err == goredis.Nil {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to pop prioritized task from %s: %w", queue, err)
+	}
+
+	values, ok := raw.([]interface{})
+	if !ok || len(values) == 0 {
+		// Script returned false (no members).
+		return false, nil
+	}
+
+	data, ok := values[0].(string)
+	if !ok {
+		return false, fmt.Errorf("unexpected ZPOPMIN member type for queue %s", queue)
+	}
+
+	if err := json.Unmarshal([]byte(data), result); err != nil {
+		return false, fmt.Errorf("failed to unmarshal prioritized task: %w", err)
+	}
+
+	return true, nil
+}
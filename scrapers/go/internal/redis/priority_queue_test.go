@@ -0,0 +1,129 @@
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/sirupsen/logrus"
+
+	"github.com/jobspy/scrapers/internal/protocol"
+)
+
+func newTestClient(t *testing.T) (*Client, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	t.Cleanup(mr.Close)
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	cfg := DefaultConfig()
+	cfg.URL = "redis://" + mr.Addr()
+
+	client, err := NewClient(cfg, logger)
+	if err != nil {
+		t.Fatalf("failed to create redis client: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	return client, mr
+}
+
+// TestPushPopPrioritizedTask covers the regression behind the priority queue
+// backend: PopPrioritizedTask must actually return the task ZPOPMIN just
+// removed, not report the queue empty while silently discarding it.
+func TestPushPopPrioritizedTask(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	queue := "scraping:tasks:{indeed}"
+	task := &protocol.ScrapingTask{
+		TaskID:      "priority-test-task",
+		ScraperType: protocol.ScraperTypeIndeed,
+		Params: protocol.ScrapingTaskParams{
+			SearchTerm:    "golang",
+			Location:      "remote",
+			ResultsWanted: 10,
+		},
+		Timeout:  30,
+		Priority: 5,
+	}
+
+	if err := client.PushPrioritizedTask(queue, task); err != nil {
+		t.Fatalf("PushPrioritizedTask failed: %v", err)
+	}
+
+	var popped protocol.ScrapingTask
+	available, err := client.PopPrioritizedTask(queue, time.Second, &popped)
+	if err != nil {
+		t.Fatalf("PopPrioritizedTask failed: %v", err)
+	}
+	if !available {
+		t.Fatal("PopPrioritizedTask reported the queue empty right after a push")
+	}
+	if popped.TaskID != task.TaskID {
+		t.Fatalf("popped task ID = %q, want %q", popped.TaskID, task.TaskID)
+	}
+
+	// The task must actually be gone now, not merely hidden.
+	available, err = client.PopPrioritizedTask(queue, 50*time.Millisecond, &popped)
+	if err != nil {
+		t.Fatalf("PopPrioritizedTask (second pop) failed: %v", err)
+	}
+	if available {
+		t.Fatal("PopPrioritizedTask returned a task after the queue should have been drained")
+	}
+}
+
+// TestPopPrioritizedTask_OrdersByPriorityThenFIFO confirms the highest
+// priority task is always returned first, and that same-priority tasks come
+// back in the order they were pushed.
+func TestPopPrioritizedTask_OrdersByPriorityThenFIFO(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	queue := "scraping:tasks:{indeed}"
+
+	makeTask := func(id string, priority int) *protocol.ScrapingTask {
+		return &protocol.ScrapingTask{
+			TaskID:      id,
+			ScraperType: protocol.ScraperTypeIndeed,
+			Params: protocol.ScrapingTaskParams{
+				SearchTerm:    "golang",
+				Location:      "remote",
+				ResultsWanted: 10,
+			},
+			Timeout:  30,
+			Priority: priority,
+		}
+	}
+
+	for _, task := range []*protocol.ScrapingTask{
+		makeTask("low-1", 1),
+		makeTask("high-1", 9),
+		makeTask("low-2", 1),
+		makeTask("high-2", 9),
+	} {
+		if err := client.PushPrioritizedTask(queue, task); err != nil {
+			t.Fatalf("PushPrioritizedTask(%s) failed: %v", task.TaskID, err)
+		}
+	}
+
+	var want = []string{"high-1", "high-2", "low-1", "low-2"}
+	for _, id := range want {
+		var popped protocol.ScrapingTask
+		available, err := client.PopPrioritizedTask(queue, time.Second, &popped)
+		if err != nil {
+			t.Fatalf("PopPrioritizedTask failed: %v", err)
+		}
+		if !available {
+			t.Fatalf("expected a task but queue reported empty (wanted %s)", id)
+		}
+		if popped.TaskID != id {
+			t.Fatalf("popped task ID = %q, want %q", popped.TaskID, id)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+
+	"github.com/jobspy/scrapers/internal/ratelimit"
+)
+
+// SetLimiterState implements ratelimit.Persister, so a ratelimit.AdaptiveLimiter
+// survives a worker restart without resuming at its ceiling (or, worse,
+// forgetting a halving whose cooldown hasn't elapsed yet). ttl is the
+// limiter's own persistTTL, not tied to any task.
+func (c *Client) SetLimiterState(tuple string, state ratelimit.State, ttl time.Duration) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal rate limiter state for %s: %w", tuple, err)
+	}
+
+	ctx, cancel := context.WithTimeout(c.ctx, c.config.WriteTimeout)
+	defer cancel()
+
+	if err := c.client.Set(ctx, rateLimiterKey(tuple), data, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to set rate limiter state for %s: %w", tuple, err)
+	}
+	return nil
+}
+
+// GetLimiterState implements ratelimit.Persister. It reports false, nil if
+// tuple has no persisted state, e.g. it hasn't been seen since the last
+// restart or the TTL has expired.
+func (c *Client) GetLimiterState(tuple string) (*ratelimit.State, bool, error) {
+	ctx, cancel := context.WithTimeout(c.ctx, c.config.ReadTimeout)
+	defer cancel()
+
+	data, err := c.client.Get(ctx, rateLimiterKey(tuple)).Result()
+	if err != nil {
+		if err == goredis.Nil {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to get rate limiter state for %s: %w", tuple, err)
+	}
+
+	var state ratelimit.State
+	if err := json.Unmarshal([]byte(data), &state); err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal rate limiter state for %s: %w", tuple, err)
+	}
+	return &state, true, nil
+}
+
+// rateLimiterKey is the Redis key a tuple's persisted limiter state is
+// stored under.
+func rateLimiterKey(tuple string) string {
+	return "scraping:ratelimit:" + tuple
+}
@@ -0,0 +1,42 @@
+package redis
+
+import (
+	"hash/fnv"
+	"time"
+
+	"github.com/jobspy/scrapers/internal/protocol"
+)
+
+// ScheduleRecurring enqueues task's first run at a deterministic, per-task
+// phase-shifted offset within interval, rather than at interval's boundary,
+// so a fleet of recurring tasks spreads out instead of all hammering their
+// targets at once - the same thundering-herd avoidance Prometheus uses when
+// staggering scrape targets (see scraper.SleepJittered for the analogous
+// per-request case). It reuses the delayed-retry ZSET machinery: the task is
+// scheduled onto the scraper type's delayed queue and the RetryDispatcher
+// picks it up and pushes it to the live task queue once due. The caller owns
+// actually re-invoking ScheduleRecurring every interval; this only staggers
+// the phase of the first enqueue.
+func (c *Client) ScheduleRecurring(task *protocol.ScrapingTask, interval time.Duration) error {
+	scraperType := protocol.ScraperType(task.ScraperType)
+	delayedQueue := protocol.GetDelayedRetryQueue(scraperType)
+	destQueue := protocol.GetTaskQueue(scraperType)
+
+	offset := taskPhaseOffset(task.TaskID, interval)
+	runAt := time.Now().UTC().Add(offset)
+
+	return c.scheduleDelayed(delayedQueue, destQueue, task, runAt)
+}
+
+// taskPhaseOffset deterministically maps taskID to a duration in [0, interval)
+// so that repeated calls for the same task always land at the same phase
+// within the interval.
+func taskPhaseOffset(taskID string, interval time.Duration) time.Duration {
+	if interval <= 0 {
+		return 0
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(taskID))
+	return time.Duration(h.Sum64() % uint64(interval))
+}
@@ -0,0 +1,224 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/sirupsen/logrus"
+
+	"github.com/jobspy/scrapers/internal/protocol"
+)
+
+// retryClaimScript atomically claims every delayed-retry member whose score
+// (a unix-millis due time) is no later than now, removing each from the
+// ZSET in the same round trip so two dispatcher instances can never both
+// claim the same task.
+var retryClaimScript = goredis.NewScript(`
+local members = redis.call('ZRANGEBYSCORE', KEYS[1], '0', ARGV[1], 'LIMIT', 0, ARGV[2])
+if #members > 0 then
+	redis.call('ZREM', KEYS[1], unpack(members))
+end
+return members
+`)
+
+// retryEntry is what's actually stored in the delayed-retry ZSET: the task
+// plus the exact live queue it should be re-enqueued onto once due. Carrying
+// the destination queue alongside the task avoids having to re-derive it
+// from task.Priority, which (unlike the ZSET-scored priority queue scheme in
+// priority_queue.go) the urgent/normal/backfill band queues aren't keyed by.
+type retryEntry struct {
+	Queue string                `json:"queue"`
+	Task  protocol.ScrapingTask `json:"task"`
+}
+
+// ScheduleRetry stores task in its scraper type's delayed-retry ZSET, scored
+// by runAt, so a RetryDispatcher polling the same ZSET re-enqueues it onto
+// queue once it's actually due.
+func (c *Client) ScheduleRetry(queue string, task *protocol.ScrapingTask, runAt time.Time) error {
+	delayedQueue := protocol.GetDelayedRetryQueue(protocol.ScraperType(task.ScraperType))
+	return c.scheduleDelayed(delayedQueue, queue, task, runAt)
+}
+
+// scheduleDelayed stores task (tagged with the destQueue it should
+// eventually be re-enqueued onto) in delayedQueue's ZSET, scored by runAt.
+// Shared by ScheduleRetry and ScheduleRecurring, both of which are really
+// "push this onto destQueue once runAt arrives" with different callers.
+func (c *Client) scheduleDelayed(delayedQueue, destQueue string, task *protocol.ScrapingTask, runAt time.Time) error {
+	data, err := json.Marshal(retryEntry{Queue: destQueue, Task: *task})
+	if err != nil {
+		return fmt.Errorf("failed to marshal delayed-queue entry: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(c.ctx, c.config.WriteTimeout)
+	defer cancel()
+
+	score := float64(runAt.UnixMilli())
+	if err := c.client.ZAdd(ctx, delayedQueue, &goredis.Z{Score: score, Member: data}).Err(); err != nil {
+		return fmt.Errorf("failed to schedule delayed push onto %s: %w", delayedQueue, err)
+	}
+
+	return nil
+}
+
+// claimDueRetries claims up to limit due members of queue, returning their
+// raw JSON payloads. Members already popped by another dispatcher instance
+// simply won't be among those returned.
+func (c *Client) claimDueRetries(queue string, now time.Time, limit int64) ([]string, error) {
+	ctx, cancel := context.WithTimeout(c.ctx, c.config.ReadTimeout)
+	defer cancel()
+
+	raw, err := retryClaimScript.Run(ctx, c.client, []string{queue}, now.UnixMilli(), limit).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim due retries from %s: %w", queue, err)
+	}
+
+	values, ok := raw.([]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	members := make([]string, 0, len(values))
+	for _, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+		members = append(members, s)
+	}
+	return members, nil
+}
+
+// ComputeBackoff returns min(maxBackoff, base*2^retryCount) with up to 20%
+// jitter added, mirroring scraper.JitteredDelay's bounded-uniform approach
+// but as an independent helper since retry scheduling is a different
+// subsystem from per-request pacing.
+func ComputeBackoff(base, maxBackoff time.Duration, retryCount int) time.Duration {
+	if retryCount < 0 {
+		retryCount = 0
+	}
+
+	backoff := base
+	for i := 0; i < retryCount; i++ {
+		if backoff >= maxBackoff {
+			backoff = maxBackoff
+			break
+		}
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+
+	jitter := time.Duration(rand.Int63n(int64(backoff)/5 + 1))
+	return backoff + jitter
+}
+
+// RetryDispatcher polls a scraper type's delayed-retry ZSET and re-enqueues
+// due tasks onto the live queue each was scheduled for. It never
+// dead-letters: a task that has exhausted MaxRetries is dead-lettered by its
+// caller instead of being scheduled here. One dispatcher is started per
+// worker process; claimDueRetries'
+// atomic ZRANGEBYSCORE+ZREM keeps concurrent dispatchers from double-firing
+// the same retry.
+type RetryDispatcher struct {
+	redisClient *Client
+	logger      *logrus.Logger
+	scraperType protocol.ScraperType
+	backend     QueueBackend
+	interval    time.Duration
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+// NewRetryDispatcher creates a dispatcher for scraperType's delayed-retry
+// queue. backend determines whether a due retry is re-enqueued onto the
+// list, stream or priority live queue.
+func NewRetryDispatcher(redisClient *Client, logger *logrus.Logger, scraperType protocol.ScraperType, backend QueueBackend, pollInterval time.Duration) *RetryDispatcher {
+	return &RetryDispatcher{
+		redisClient: redisClient,
+		logger:      logger,
+		scraperType: scraperType,
+		backend:     backend,
+		interval:    pollInterval,
+		stopCh:      make(chan struct{}),
+		doneCh:      make(chan struct{}),
+	}
+}
+
+// Start runs the poll loop until ctx is cancelled or Stop is called.
+func (d *RetryDispatcher) Start(ctx context.Context) {
+	go d.run(ctx)
+}
+
+// Stop signals the poll loop to exit and waits for it to do so.
+func (d *RetryDispatcher) Stop() {
+	close(d.stopCh)
+	<-d.doneCh
+}
+
+func (d *RetryDispatcher) run(ctx context.Context) {
+	defer close(d.doneCh)
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			d.dispatchDue()
+		case <-d.stopCh:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+const retryClaimBatchSize = 50
+
+func (d *RetryDispatcher) dispatchDue() {
+	delayedQueue := protocol.GetDelayedRetryQueue(d.scraperType)
+
+	members, err := d.redisClient.claimDueRetries(delayedQueue, time.Now().UTC(), retryClaimBatchSize)
+	if err != nil {
+		d.logger.WithError(err).Warn("Retry dispatcher failed to claim due retries")
+		return
+	}
+
+	for _, data := range members {
+		var entry retryEntry
+		if err := json.Unmarshal([]byte(data), &entry); err != nil {
+			d.logger.WithError(err).Warn("Retry dispatcher failed to decode claimed retry, dropping it")
+			continue
+		}
+		d.redispatch(&entry)
+	}
+}
+
+func (d *RetryDispatcher) redispatch(entry *retryEntry) {
+	logger := d.logger.WithField("task_id", entry.Task.TaskID)
+
+	switch d.backend {
+	case QueueBackendStream:
+		if _, err := d.redisClient.PushTaskStream(entry.Queue, &entry.Task); err != nil {
+			logger.WithError(err).Error("Retry dispatcher failed to resubmit due task onto stream")
+		}
+	case QueueBackendPriority:
+		if err := d.redisClient.PushPrioritizedTask(entry.Queue, &entry.Task); err != nil {
+			logger.WithError(err).Error("Retry dispatcher failed to resubmit due task onto priority queue")
+		}
+	default:
+		// dispatchDue can claim up to retryClaimBatchSize due retries in
+		// one tick; PushTaskBatched lets them share one pipelined round
+		// trip instead of one LPUSH each (falls back to PushTask's
+		// per-call behavior when the client has no PipePeriod configured).
+		if err := d.redisClient.PushTaskBatched(entry.Queue, &entry.Task); err != nil {
+			logger.WithError(err).Error("Retry dispatcher failed to resubmit due task onto queue")
+		}
+	}
+}
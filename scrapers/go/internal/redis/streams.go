@@ -0,0 +1,204 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/sirupsen/logrus"
+)
+
+// QueueBackend selects how the task queue subsystem is implemented.
+type QueueBackend string
+
+const (
+	// QueueBackendList is the original LPUSH/BRPOP queue: simple, but a
+	// popped task is gone the instant BRPop returns, so a worker crash
+	// mid-scrape silently loses it.
+	QueueBackendList QueueBackend = "list"
+	// QueueBackendStream uses a Redis Stream with a per-scraper-type
+	// consumer group: a popped task stays in the group's pending entries
+	// list until explicitly XACK'd, so ClaimStalePending can reassign it if
+	// its worker dies before finishing.
+	QueueBackendStream QueueBackend = "stream"
+	// QueueBackendPriority uses the single ZSET-backed priority queue (see
+	// priority_queue.go): every task for a scraper type lives in one queue,
+	// ordered by ScrapingTask.Priority (then FIFO within the same
+	// priority) instead of the fixed urgent/normal/backfill bands
+	// QueueBackendList's weighted fair-queueing dequeues from.
+	QueueBackendPriority QueueBackend = "priority"
+)
+
+// streamPayloadField is the single field name tasks are stored under in
+// each stream entry, keeping the wire format a plain JSON blob identical to
+// the list backend's rather than spreading the struct across fields.
+const streamPayloadField = "payload"
+
+// EnsureConsumerGroup creates group on stream if it doesn't already exist,
+// creating the stream itself via MKSTREAM if needed. It's idempotent and
+// safe to call on every worker startup.
+func (c *Client) EnsureConsumerGroup(stream, group string) error {
+	ctx, cancel := context.WithTimeout(c.ctx, c.config.WriteTimeout)
+	defer cancel()
+
+	err := c.client.XGroupCreateMkStream(ctx, stream, group, "$").Err()
+	if err != nil && !isBusyGroupErr(err) {
+		return fmt.Errorf("failed to create consumer group %s on stream %s: %w", group, stream, err)
+	}
+	return nil
+}
+
+func isBusyGroupErr(err error) bool {
+	return strings.Contains(err.Error(), "BUSYGROUP")
+}
+
+// PushTaskStream enqueues task onto stream via XADD, returning the stream
+// message ID Redis assigned it.
+func (c *Client) PushTaskStream(stream string, task interface{}) (string, error) {
+	data, err := json.Marshal(task)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal task: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(c.ctx, c.config.WriteTimeout)
+	defer cancel()
+
+	id, err := c.client.XAdd(ctx, &goredis.XAddArgs{
+		Stream: stream,
+		Values: map[string]interface{}{streamPayloadField: data},
+	}).Result()
+	if err != nil {
+		return "", fmt.Errorf("failed to XADD task to stream %s: %w", stream, err)
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"stream":     stream,
+		"message_id": id,
+	}).Debug("Task pushed to stream")
+
+	return id, nil
+}
+
+// PopTaskStream reads the next unseen message for consumer within group,
+// blocking up to timeout. The returned message ID must be passed to AckTask
+// once the task is fully processed, or it stays in the pending entries list
+// for ClaimStalePending to eventually reassign.
+func (c *Client) PopTaskStream(stream, group, consumer string, timeout time.Duration, result interface{}) (bool, string, error) {
+	ctx, cancel := context.WithTimeout(c.ctx, timeout+5*time.Second)
+	defer cancel()
+
+	streams, err := c.client.XReadGroup(ctx, &goredis.XReadGroupArgs{
+		Group:    group,
+		Consumer: consumer,
+		Streams:  []string{stream, ">"},
+		Count:    1,
+		Block:    timeout,
+	}).Result()
+	if err != nil {
+		if err == goredis.Nil {
+			return false, "", nil
+		}
+		return false, "", fmt.Errorf("failed to XREADGROUP from stream %s: %w", stream, err)
+	}
+
+	if len(streams) == 0 || len(streams[0].Messages) == 0 {
+		return false, "", nil
+	}
+
+	msg := streams[0].Messages[0]
+	if err := decodeStreamMessage(msg, result); err != nil {
+		return false, "", err
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"stream":     stream,
+		"group":      group,
+		"message_id": msg.ID,
+	}).Debug("Task popped from stream")
+
+	return true, msg.ID, nil
+}
+
+// AckTask acknowledges a stream message as fully processed, removing it
+// from the consumer group's pending entries list.
+func (c *Client) AckTask(stream, group, messageID string) error {
+	ctx, cancel := context.WithTimeout(c.ctx, c.config.WriteTimeout)
+	defer cancel()
+
+	if err := c.client.XAck(ctx, stream, group, messageID).Err(); err != nil {
+		return fmt.Errorf("failed to XACK message %s on stream %s: %w", messageID, stream, err)
+	}
+	return nil
+}
+
+// StalePendingTask is a message reclaimed from a dead consumer by
+// ClaimStalePending, ready to be unmarshaled and retried by its new owner.
+type StalePendingTask struct {
+	MessageID string
+	Task      json.RawMessage
+}
+
+// ClaimStalePending reassigns messages that have sat unacknowledged for at
+// least minIdle (their original consumer most likely died mid-scrape) to
+// consumer, using XAUTOCLAIM so a supervisor can periodically sweep for
+// lost work without tracking its own cursor into the pending entries list.
+func (c *Client) ClaimStalePending(stream, group string, minIdle time.Duration, consumer string) ([]StalePendingTask, error) {
+	ctx, cancel := context.WithTimeout(c.ctx, c.config.ReadTimeout)
+	defer cancel()
+
+	messages, _, err := c.client.XAutoClaim(ctx, &goredis.XAutoClaimArgs{
+		Stream:   stream,
+		Group:    group,
+		MinIdle:  minIdle,
+		Start:    "0-0",
+		Consumer: consumer,
+	}).Result()
+	if err != nil {
+		if err == goredis.Nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to XAUTOCLAIM stale messages on stream %s: %w", stream, err)
+	}
+
+	claimed := make([]StalePendingTask, 0, len(messages))
+	for _, msg := range messages {
+		raw, ok := msg.Values[streamPayloadField]
+		if !ok {
+			continue
+		}
+		payload, ok := raw.(string)
+		if !ok {
+			continue
+		}
+		claimed = append(claimed, StalePendingTask{MessageID: msg.ID, Task: json.RawMessage(payload)})
+	}
+
+	if len(claimed) > 0 {
+		c.logger.WithFields(logrus.Fields{
+			"stream":   stream,
+			"group":    group,
+			"consumer": consumer,
+			"count":    len(claimed),
+		}).Warn("Reclaimed stale pending stream messages")
+	}
+
+	return claimed, nil
+}
+
+func decodeStreamMessage(msg goredis.XMessage, result interface{}) error {
+	raw, ok := msg.Values[streamPayloadField]
+	if !ok {
+		return fmt.Errorf("stream message %s missing %q field", msg.ID, streamPayloadField)
+	}
+	payload, ok := raw.(string)
+	if !ok {
+		return fmt.Errorf("stream message %s %q field is not a string", msg.ID, streamPayloadField)
+	}
+	if err := json.Unmarshal([]byte(payload), result); err != nil {
+		return fmt.Errorf("failed to unmarshal stream task: %w", err)
+	}
+	return nil
+}
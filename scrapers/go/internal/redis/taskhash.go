@@ -0,0 +1,88 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+
+	"github.com/jobspy/scrapers/internal/protocol"
+)
+
+// TaskHashRecord is what SetTaskHash stores against a protocol.TaskHash, so
+// GetTaskHash (and the "worker search --hash" CLI command built on it) can
+// resolve a bare hash back to the task it was computed from.
+type TaskHashRecord struct {
+	TaskID      string `json:"task_id"`
+	ScraperType string `json:"scraper_type"`
+	SearchTerm  string `json:"search_term"`
+	Location    string `json:"location"`
+	Region      string `json:"region"`
+	RuleVersion string `json:"rule_version"`
+	CreatedAt   string `json:"created_at"`
+}
+
+// SetTaskHash records task's hash -> metadata mapping with the given TTL
+// (the task's own timeout is the natural choice - once a task can no longer
+// still be running, its hash stops being useful for callback/challenge
+// correlation). It's a no-op if hash is empty, so callers don't need to
+// guard ComputeTaskHash failures themselves.
+func (c *Client) SetTaskHash(hash string, task *protocol.ScrapingTask, region, ruleVersion string, ttl time.Duration) error {
+	if hash == "" {
+		return nil
+	}
+
+	record := TaskHashRecord{
+		TaskID:      task.TaskID,
+		ScraperType: string(task.ScraperType),
+		SearchTerm:  task.Params.SearchTerm,
+		Location:    task.Params.Location,
+		Region:      region,
+		RuleVersion: ruleVersion,
+		CreatedAt:   time.Now().UTC().Format(time.RFC3339),
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task hash record for %s: %w", hash, err)
+	}
+
+	ctx, cancel := context.WithTimeout(c.ctx, c.config.WriteTimeout)
+	defer cancel()
+
+	if err := c.client.Set(ctx, taskHashKey(hash), data, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to set task hash record for %s: %w", hash, err)
+	}
+	return nil
+}
+
+// GetTaskHash resolves hash back to the task metadata SetTaskHash recorded
+// for it, the same "search FFUFHASH" capability ffuf offers for correlating
+// a blind callback back to the request that caused it. It reports false,
+// nil if the hash is unknown or has expired.
+func (c *Client) GetTaskHash(hash string) (*TaskHashRecord, bool, error) {
+	ctx, cancel := context.WithTimeout(c.ctx, c.config.ReadTimeout)
+	defer cancel()
+
+	data, err := c.client.Get(ctx, taskHashKey(hash)).Result()
+	if err != nil {
+		if err == goredis.Nil {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to get task hash record for %s: %w", hash, err)
+	}
+
+	var record TaskHashRecord
+	if err := json.Unmarshal([]byte(data), &record); err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal task hash record for %s: %w", hash, err)
+	}
+	return &record, true, nil
+}
+
+// taskHashKey is the Redis key a task's hash -> metadata mapping is stored
+// under.
+func taskHashKey(hash string) string {
+	return "scraping:taskhash:" + hash
+}
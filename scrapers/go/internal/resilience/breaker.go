@@ -0,0 +1,196 @@
+package resilience
+
+import (
+	"sync"
+	"time"
+)
+
+// BreakerState is one of the three states in the classic circuit-breaker
+// state machine.
+type BreakerState int
+
+const (
+	StateClosed BreakerState = iota
+	StateOpen
+	StateHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// CircuitBreaker guards calls to a single upstream (e.g. one host): it
+// opens after failureThreshold consecutive failures, or once the error
+// rate over the trailing window exceeds errorRateThreshold, whichever
+// comes first. Once cooldown has elapsed it allows exactly one half-open
+// probe call through before deciding whether to close again or re-open.
+//
+// A CircuitBreaker tracks one upstream only - see BreakerRegistry for a
+// pool keyed by host.
+type CircuitBreaker struct {
+	mu sync.Mutex
+
+	failureThreshold   int
+	errorRateThreshold float64
+	window             time.Duration
+	cooldown           time.Duration
+
+	state               BreakerState
+	openedAt            time.Time
+	consecutiveFailures int
+	events              []breakerEvent
+}
+
+type breakerEvent struct {
+	at      time.Time
+	success bool
+}
+
+// NewCircuitBreaker builds a CircuitBreaker with the given thresholds. See
+// the type doc comment for what each parameter controls.
+func NewCircuitBreaker(failureThreshold int, errorRateThreshold float64, window, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		failureThreshold:   failureThreshold,
+		errorRateThreshold: errorRateThreshold,
+		window:             window,
+		cooldown:           cooldown,
+		state:              StateClosed,
+	}
+}
+
+// Allow reports whether a call against the guarded upstream should proceed.
+// When the breaker is open and cooldown has elapsed, the first caller to
+// observe that transitions it to half-open and is let through as the
+// probe; callers that arrive while a probe is outstanding are refused.
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = StateHalfOpen
+		return true
+	case StateHalfOpen:
+		return false
+	default:
+		return true
+	}
+}
+
+// RecordSuccess reports a successful call, closing the breaker.
+func (b *CircuitBreaker) RecordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.consecutiveFailures = 0
+	b.record(true)
+	b.state = StateClosed
+}
+
+// RecordFailure reports a failed call, tripping the breaker open if the
+// failure thresholds are met, or immediately if this was the half-open
+// probe.
+func (b *CircuitBreaker) RecordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.consecutiveFailures++
+	b.record(false)
+
+	if b.state == StateHalfOpen {
+		b.trip()
+		return
+	}
+	if b.consecutiveFailures >= b.failureThreshold || b.errorRate() > b.errorRateThreshold {
+		b.trip()
+	}
+}
+
+func (b *CircuitBreaker) trip() {
+	b.state = StateOpen
+	b.openedAt = time.Now()
+}
+
+// record appends an outcome and discards events outside the rolling window.
+func (b *CircuitBreaker) record(success bool) {
+	now := time.Now()
+	b.events = append(b.events, breakerEvent{at: now, success: success})
+
+	cutoff := now.Add(-b.window)
+	kept := b.events[:0]
+	for _, e := range b.events {
+		if e.at.After(cutoff) {
+			kept = append(kept, e)
+		}
+	}
+	b.events = kept
+}
+
+func (b *CircuitBreaker) errorRate() float64 {
+	if len(b.events) == 0 {
+		return 0
+	}
+	failures := 0
+	for _, e := range b.events {
+		if !e.success {
+			failures++
+		}
+	}
+	return float64(failures) / float64(len(b.events))
+}
+
+// State returns the breaker's current state, mainly for health reporting.
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// BreakerRegistry hands out a CircuitBreaker per key (typically a host),
+// creating one lazily on first use with the registry's configured
+// thresholds. It mirrors scraper.SiteRegistry's per-site map pattern.
+type BreakerRegistry struct {
+	mu       sync.Mutex
+	breakers map[string]*CircuitBreaker
+
+	failureThreshold   int
+	errorRateThreshold float64
+	window             time.Duration
+	cooldown           time.Duration
+}
+
+// NewBreakerRegistry builds a registry that constructs breakers with the
+// given thresholds the first time each key is requested via Get.
+func NewBreakerRegistry(failureThreshold int, errorRateThreshold float64, window, cooldown time.Duration) *BreakerRegistry {
+	return &BreakerRegistry{
+		breakers:           make(map[string]*CircuitBreaker),
+		failureThreshold:   failureThreshold,
+		errorRateThreshold: errorRateThreshold,
+		window:             window,
+		cooldown:           cooldown,
+	}
+}
+
+// Get returns the CircuitBreaker for key, creating it if this is the first
+// call for that key.
+func (r *BreakerRegistry) Get(key string) *CircuitBreaker {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, ok := r.breakers[key]
+	if !ok {
+		b = NewCircuitBreaker(r.failureThreshold, r.errorRateThreshold, r.window, r.cooldown)
+		r.breakers[key] = b
+	}
+	return b
+}
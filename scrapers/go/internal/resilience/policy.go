@@ -0,0 +1,44 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+)
+
+// RetryPolicy decides whether an error is worth retrying. It replaces
+// matching error strings against a hardcoded list of substrings, which
+// breaks silently whenever an error's wording changes.
+//
+// Classify lets a caller teach the policy about its own error types (e.g.
+// a scraper package's ScrapingError) without this package having to import
+// them and risk an import cycle. It returns matched=false to fall through
+// to the policy's own defaults.
+type RetryPolicy struct {
+	Classify func(err error) (retryable, matched bool)
+}
+
+// NewRetryPolicy builds a RetryPolicy that consults classify before falling
+// back to its own defaults (non-retryable context cancellation/deadline
+// errors, non-retryable everything else). classify may be nil.
+func NewRetryPolicy(classify func(err error) (retryable, matched bool)) *RetryPolicy {
+	return &RetryPolicy{Classify: classify}
+}
+
+// IsRetryable reports whether err should trigger another attempt.
+func (p *RetryPolicy) IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if p.Classify != nil {
+		if retryable, matched := p.Classify(err); matched {
+			return retryable
+		}
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	return false
+}
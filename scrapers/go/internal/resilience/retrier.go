@@ -0,0 +1,54 @@
+// Package resilience holds retry and circuit-breaking building blocks that
+// are shared across packages instead of being reimplemented per-caller
+// (previously Worker and JobSpyAPIClient each grew their own bespoke
+// backoff and error-classification logic).
+package resilience
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Retrier computes full-jitter exponential backoff delays, per the AWS
+// "Exponential Backoff And Jitter" algorithm: each delay is drawn uniformly
+// from [0, min(cap, base*2^attempt)) rather than following a fixed
+// exponential curve, which spreads out retrying callers instead of having
+// them retry in lockstep.
+type Retrier struct {
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+	MaxRetries int
+}
+
+// NewRetrier builds a Retrier with baseDelay*2^attempt capped at maxDelay,
+// allowing up to maxRetries attempts beyond the first.
+func NewRetrier(baseDelay, maxDelay time.Duration, maxRetries int) *Retrier {
+	return &Retrier{BaseDelay: baseDelay, MaxDelay: maxDelay, MaxRetries: maxRetries}
+}
+
+// NextDelay returns the full-jitter backoff delay for the given attempt
+// (0-indexed: the first retry after the initial failure is attempt 0).
+func (r *Retrier) NextDelay(attempt int) time.Duration {
+	if attempt < 0 {
+		attempt = 0
+	}
+
+	cap := r.BaseDelay
+	for i := 0; i < attempt && cap < r.MaxDelay; i++ {
+		cap *= 2
+	}
+	if cap > r.MaxDelay {
+		cap = r.MaxDelay
+	}
+	if cap <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(cap)))
+}
+
+// ShouldRetry reports whether attempt (0-indexed, as passed to NextDelay)
+// is still within the configured retry budget.
+func (r *Retrier) ShouldRetry(attempt int) bool {
+	return attempt < r.MaxRetries
+}
@@ -0,0 +1,119 @@
+// Package salary normalizes the salary figures scrapers pull out of job
+// postings - reported at whatever cadence and in whatever currency the
+// source site used - into the annual, USD-comparable SalaryMin/Max/Currency
+// shape protocol.JobData expects.
+package salary
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Period is the cadence a salary figure was reported in.
+type Period string
+
+const (
+	PeriodHourly  Period = "hourly"
+	PeriodDaily   Period = "daily"
+	PeriodWeekly  Period = "weekly"
+	PeriodMonthly Period = "monthly"
+	PeriodYearly  Period = "yearly"
+)
+
+// Standard full-time conversion factors used to annualize a non-yearly
+// salary figure. These are approximations (a real posting's hours/week
+// varies), which is the best any salary aggregator can do without the
+// underlying employment contract.
+const (
+	hoursPerWeek  = 40
+	daysPerWeek   = 5
+	weeksPerYear  = 52
+	monthsPerYear = 12
+)
+
+// EnforceAnnualSalary converts min/max from the given period to an annual
+// figure. Values already reported yearly, or with an unrecognized/empty
+// period, are returned unchanged.
+func EnforceAnnualSalary(min, max *float64, period Period) (*float64, *float64) {
+	factor, ok := annualFactor(period)
+	if !ok {
+		return min, max
+	}
+	return scale(min, factor), scale(max, factor)
+}
+
+func annualFactor(period Period) (float64, bool) {
+	switch Period(strings.ToLower(string(period))) {
+	case PeriodHourly:
+		return hoursPerWeek * weeksPerYear, true
+	case PeriodDaily:
+		return daysPerWeek * weeksPerYear, true
+	case PeriodWeekly:
+		return weeksPerYear, true
+	case PeriodMonthly:
+		return monthsPerYear, true
+	default:
+		return 0, false
+	}
+}
+
+func scale(amount *float64, factor float64) *float64 {
+	if amount == nil {
+		return nil
+	}
+	scaled := *amount * factor
+	return &scaled
+}
+
+// FXProvider converts an amount from one currency to another. It lets
+// callers plug in a live exchange-rate source without this package having
+// to depend on one.
+type FXProvider interface {
+	Convert(amount float64, from, to string) (float64, error)
+}
+
+// IdentityFXProvider is the default FXProvider: it only "converts" between
+// identical currency codes (case-insensitively) and errors otherwise, so
+// normalizing to a target currency without a real rate source fails loudly
+// instead of silently mixing currencies.
+type IdentityFXProvider struct{}
+
+func (IdentityFXProvider) Convert(amount float64, from, to string) (float64, error) {
+	if !strings.EqualFold(from, to) {
+		return 0, fmt.Errorf("no FX rate available to convert %s to %s", from, to)
+	}
+	return amount, nil
+}
+
+// NormalizeCurrency converts min/max from currency to targetCurrency using
+// provider. If currency already matches targetCurrency, or both amounts are
+// nil, it returns min/max/currency unchanged without consulting provider.
+func NormalizeCurrency(min, max *float64, currency, targetCurrency string, provider FXProvider) (*float64, *float64, string, error) {
+	if provider == nil {
+		provider = IdentityFXProvider{}
+	}
+	if strings.EqualFold(currency, targetCurrency) || (min == nil && max == nil) {
+		return min, max, currency, nil
+	}
+
+	convertedMin, err := convertAmount(min, currency, targetCurrency, provider)
+	if err != nil {
+		return min, max, currency, err
+	}
+	convertedMax, err := convertAmount(max, currency, targetCurrency, provider)
+	if err != nil {
+		return min, max, currency, err
+	}
+	return convertedMin, convertedMax, targetCurrency, nil
+}
+
+func convertAmount(amount *float64, from, to string, provider FXProvider) (*float64, error) {
+	if amount == nil {
+		return nil, nil
+	}
+	converted, err := provider.Convert(*amount, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert salary amount from %s to %s: %w", from, to, err)
+	}
+	return &converted, nil
+}
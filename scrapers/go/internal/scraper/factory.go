@@ -6,42 +6,73 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/jobspy/scrapers/internal/protocol"
-	"github.com/jobspy/scrapers/internal/scrapers/jobspy_client"
+	"github.com/jobspy/scrapers/internal/ratelimit"
 )
 
+// defaultBackend is used when ScraperConfig.Backend is unset, so existing
+// deployments that only know about JobSpy API scraping keep working
+// unchanged.
+const defaultBackend = "jobspy"
+
 // Factory implements ScraperFactory interface
 type Factory struct {
 	logger *logrus.Logger
+
+	// siteRegistry is shared across every scraper this factory creates so
+	// concurrent workers hitting the same target site are staggered
+	// relative to each other, not just within a single client.
+	siteRegistry *SiteRegistry
+
+	// limiter is shared across every scraper this factory creates, the same
+	// way siteRegistry is, so the adaptive rate applied to a given (site,
+	// proxy, region) tuple reflects every worker's calls against it, not
+	// just one client's.
+	limiter *ratelimit.AdaptiveLimiter
 }
 
-// NewFactory creates a new scraper factory
-func NewFactory(logger *logrus.Logger) *Factory {
+// NewFactory creates a new scraper factory. persister backs the shared
+// AdaptiveLimiter's cross-restart state (see ratelimit.Persister) and may be
+// nil to disable persistence.
+func NewFactory(logger *logrus.Logger, persister ratelimit.Persister, metricsEnabled bool) *Factory {
 	return &Factory{
-		logger: logger,
+		logger:       logger,
+		siteRegistry: NewSiteRegistry(0),
+		limiter:      ratelimit.NewAdaptiveLimiter(logger, metricsEnabled, persister),
 	}
 }
 
-// CreateScraper creates a scraper instance based on type
+// CreateScraper creates a scraper instance for scraperType, built by
+// whichever backend ScraperConfig.Backend names (see registry.go). This
+// lets additional native scrapers ship as sibling packages to jobspy_client
+// and be selected per-worker purely through configuration, without Factory
+// needing to know about them by name.
 func (f *Factory) CreateScraper(scraperType protocol.ScraperType, config ScraperConfig) (Scraper, error) {
+	if !protocol.IsValidScraperType(string(scraperType)) {
+		return nil, fmt.Errorf("unsupported scraper type: %s", scraperType)
+	}
+
+	backendName := config.Backend
+	if backendName == "" {
+		backendName = defaultBackend
+	}
+
+	constructor, ok := Lookup(backendName)
+	if !ok {
+		return nil, fmt.Errorf("scraper backend %q is not registered (registered: %v)", backendName, RegisteredBackends())
+	}
+
 	f.logger.WithFields(logrus.Fields{
 		"scraper_type": scraperType,
+		"backend":      backendName,
 		"worker_id":    config.WorkerID,
-	}).Debug("Creating JobSpy API client instance")
+	}).Debug("Creating scraper instance")
 
-	switch scraperType {
-	case protocol.ScraperTypeIndeed:
-		return f.createJobSpyClient(config, "indeed")
-	case protocol.ScraperTypeLinkedIn:
-		return f.createJobSpyClient(config, "linkedin")
-	case protocol.ScraperTypeGlassdoor:
-		return f.createJobSpyClient(config, "glassdoor")
-	case protocol.ScraperTypeZipRecruiter:
-		return f.createJobSpyClient(config, "ziprecruiter")
-	case protocol.ScraperTypeGoogle:
-		return f.createJobSpyClient(config, "google")
-	default:
-		return nil, fmt.Errorf("unsupported scraper type: %s", scraperType)
+	instance, err := constructor(config, f.logger, f.siteRegistry, f.limiter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct %s scraper for %s: %w", backendName, scraperType, err)
 	}
+
+	return instance, nil
 }
 
 // GetSupportedTypes returns list of supported scraper types
@@ -54,39 +85,3 @@ func (f *Factory) GetSupportedTypes() []protocol.ScraperType {
 		protocol.ScraperTypeGoogle,
 	}
 }
-
-// createJobSpyClient creates a JobSpy API client for any scraper type
-func (f *Factory) createJobSpyClient(config ScraperConfig, scraperName string) (Scraper, error) {
-	// Apply JobSpy API client defaults
-	clientConfig := config
-	if clientConfig.BaseURL == "" {
-		// Default to local JobSpy API instance
-		clientConfig.BaseURL = "http://localhost:8000"
-	}
-	if clientConfig.ResponseTimeout == 0 {
-		clientConfig.ResponseTimeout = 60 // 60 second timeout for JobSpy API calls
-	}
-	if clientConfig.MaxIdleConns == 0 {
-		clientConfig.MaxIdleConns = 10
-	}
-	if clientConfig.MaxConnsPerHost == 0 {
-		clientConfig.MaxConnsPerHost = 10
-	}
-
-	// Set API key if provided (stored in UserAgents field for simplicity)
-	if len(clientConfig.UserAgents) == 0 {
-		clientConfig.UserAgents = []string{""} // Empty API key by default
-	}
-
-	client := jobspy_client.NewJobSpyAPIClient(clientConfig, f.logger)
-	if err := client.Configure(clientConfig); err != nil {
-		return nil, fmt.Errorf("failed to configure JobSpy API client for %s: %w", scraperName, err)
-	}
-
-	f.logger.WithFields(logrus.Fields{
-		"api_url":      clientConfig.BaseURL,
-		"scraper_type": scraperName,
-	}).Info("JobSpy API client created")
-	return client, nil
-}
-
@@ -19,7 +19,13 @@ type Scraper interface {
 	
 	// Configure sets up the scraper with given parameters
 	Configure(config ScraperConfig) error
-	
+
+	// ReloadConfig applies a hot-reloaded configuration in place. Only
+	// mutable fields (proxy pool, user agents, delay bounds, rate limits)
+	// are expected to change; implementations should be safe to call while
+	// ScrapeJobs is running concurrently.
+	ReloadConfig(config ScraperConfig) error
+
 	// ScrapeJobs performs the actual job scraping
 	ScrapeJobs(ctx context.Context, params protocol.ScrapingTaskParams) (*protocol.ScrapingResult, error)
 	
@@ -33,6 +39,62 @@ type Scraper interface {
 	Close() error
 }
 
+// StreamingScraper is an optional extension of Scraper for backends that can
+// emit incremental protocol.TaskUpdate messages (pages scraped, partial job
+// batches) while a scrape is still running, instead of only returning a
+// ScrapingResult at the end. Callers should type-assert a Scraper to this
+// interface and fall back to DefaultStreamingAdapter when unsupported.
+type StreamingScraper interface {
+	ScrapeJobsStreaming(ctx context.Context, params protocol.ScrapingTaskParams, updates chan<- protocol.TaskUpdate) (*protocol.ScrapingResult, error)
+}
+
+// streamingAdapter adapts a plain Scraper to StreamingScraper by running the
+// non-streaming ScrapeJobs and emitting a single progress update once it
+// completes, so callers have one code path regardless of backend support.
+type streamingAdapter struct {
+	Scraper
+}
+
+// DefaultStreamingAdapter wraps a Scraper that doesn't natively support
+// streaming so it still satisfies StreamingScraper, at the cost of only
+// reporting progress once the whole scrape finishes.
+func DefaultStreamingAdapter(s Scraper) StreamingScraper {
+	return streamingAdapter{Scraper: s}
+}
+
+func (a streamingAdapter) ScrapeJobsStreaming(ctx context.Context, params protocol.ScrapingTaskParams, updates chan<- protocol.TaskUpdate) (*protocol.ScrapingResult, error) {
+	result, err := a.Scraper.ScrapeJobs(ctx, params)
+	if result != nil {
+		updates <- protocol.TaskUpdate{
+			PagesScraped: result.Metadata.PagesScraped,
+			JobsBatch:    result.JobsData,
+			Type:         protocol.TaskUpdateProgress,
+		}
+	}
+	return result, err
+}
+
+// JobStreamingScraper is an optional extension of Scraper for backends that
+// can emit individual protocol.JobData entries as soon as each is decoded,
+// rather than only a page-sized batch (StreamingScraper) or the whole
+// result set (plain Scraper). out is closed by the implementation when the
+// scrape ends, whether it succeeds or fails. Callers should type-assert a
+// Scraper to this interface and fall back to StreamingScraper or plain
+// ScrapeJobs when unsupported.
+type JobStreamingScraper interface {
+	ScrapeJobsStream(ctx context.Context, params protocol.ScrapingTaskParams, out chan<- protocol.JobData) error
+}
+
+// RuleVersioner is an optional extension of Scraper for backends that load
+// scraper/rules.Rule files. RuleVersion reports the current rule-set
+// generation (see rules.RuleSet.Version) so callers such as
+// protocol.ComputeTaskHash can fold it into a task fingerprint without
+// themselves depending on the rules package. Callers should type-assert a
+// Scraper to this interface and fall back to "none" when unsupported.
+type RuleVersioner interface {
+	RuleVersion() string
+}
+
 // ScraperConfig holds configuration for a scraper
 type ScraperConfig struct {
 	// Basic configuration
@@ -42,7 +104,19 @@ type ScraperConfig struct {
 	MaxPages    int
 	PageSize    int
 	Timeout     time.Duration
-	
+
+	// Backend selects which registered scraper.Constructor (see registry.go)
+	// Factory.CreateScraper builds for this scraper type, e.g. "jobspy" or a
+	// native per-site backend registered by a sibling package. Empty
+	// defaults to "jobspy" for backward compatibility.
+	Backend string
+
+	// ScrapeRulesDir, if set, is a directory of rules.Rule files (JSON or
+	// YAML) a scraper loads at startup and hot-reloads on change - see the
+	// scraper/rules package. Empty disables rule-based extraction
+	// entirely, which is the same behavior as before this field existed.
+	ScrapeRulesDir string
+
 	// Anti-detection configuration
 	ProxyPool           []string
 	UserAgents          []string
@@ -52,10 +126,28 @@ type ScraperConfig struct {
 	RotateUserAgents    bool
 	StealthMode         bool
 	BrowserMode         bool
-	
+
+	// Dynamic proxy pool (see scraper/proxy): ProxyProviderType/ProxySource
+	// select a proxy.Provider the way config.Config documents them;
+	// ProxyRotationStrategy and ProxyHealthCheckURL configure the
+	// resulting proxy.Pool. Empty ProxyProviderType falls back to a
+	// proxy.StaticProvider over ProxyPool.
+	ProxyProviderType     string
+	ProxySource           string
+	ProxyRotationStrategy string
+	ProxyHealthCheckURL   string
+	ProxyPollInterval     time.Duration
+
+
 	// Rate limiting
 	RateLimitRPM        int
 	ConcurrentRequests  int
+
+	// RespectRetryAfter, when true, makes the ratelimit.AdaptiveLimiter
+	// backing this scraper honor a response's Retry-After header exactly
+	// (see ratelimit.ParseRetryAfter) instead of relying solely on its own
+	// AIMD-computed backoff.
+	RespectRetryAfter bool
 	
 	// HTTP client configuration
 	MaxIdleConns        int
@@ -104,6 +196,10 @@ type ProxyManager interface {
 	MarkProxySuccess(proxy string)
 	GetHealthyProxies() []string
 	GetProxyStats() map[string]ProxyStats
+
+	// ReloadPool replaces the managed proxy pool in place, e.g. in response
+	// to a config hot-reload. Proxies already in flight are unaffected.
+	ReloadPool(proxies []string) error
 }
 
 // ProxyStats holds statistics for a proxy
@@ -232,4 +328,5 @@ const (
 	ErrorTypeTimeout     = "timeout"
 	ErrorTypeValidation  = "validation"
 	ErrorTypeInternal    = "internal"
+	ErrorTypeCircuitOpen = "circuit_open"
 )
\ No newline at end of file
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/jobspy/scrapers/internal/scraper"
+)
+
+// CaptchaDetectorStats holds invocation counters for
+// CaptchaDetectorMiddleware, read via Stats().
+type CaptchaDetectorStats struct {
+	Invocations int64
+	Detections  int64
+}
+
+// captchaMarkers are substrings that reliably show up in the HTML of a
+// challenge page across the common providers this project's targets use.
+var captchaMarkers = [][]byte{
+	[]byte("captcha"),
+	[]byte("cf-challenge"),
+	[]byte("Pardon Our Interruption"),
+	[]byte("g-recaptcha"),
+}
+
+// CaptchaDetectorMiddleware inspects a successful response body for known
+// captcha/challenge-page markers and turns it into a retryable
+// scraper.ScrapingError so the retry middleware (and eventually the worker)
+// can react - e.g. rotate a proxy or escalate to browser mode - instead of
+// the caller silently parsing a challenge page as if it were job data.
+type CaptchaDetectorMiddleware struct {
+	detections int64
+	invocations int64
+}
+
+// NewCaptchaDetectorMiddleware builds a CaptchaDetectorMiddleware.
+func NewCaptchaDetectorMiddleware() *CaptchaDetectorMiddleware {
+	return &CaptchaDetectorMiddleware{}
+}
+
+func (m *CaptchaDetectorMiddleware) Name() string { return "captcha_detector" }
+
+func (m *CaptchaDetectorMiddleware) Process(req *http.Request, next Handler) (*http.Response, error) {
+	atomic.AddInt64(&m.invocations, 1)
+
+	resp, err := next(req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+
+	body, readErr := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if readErr != nil {
+		return resp, readErr
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	for _, marker := range captchaMarkers {
+		if bytes.Contains(body, marker) {
+			atomic.AddInt64(&m.detections, 1)
+			return resp, scraper.ScrapingError{
+				Type:       scraper.ErrorTypeCaptcha,
+				Message:    "captcha or challenge page detected",
+				URL:        req.URL.String(),
+				StatusCode: resp.StatusCode,
+				Retryable:  true,
+			}
+		}
+	}
+
+	return resp, nil
+}
+
+// Stats returns a snapshot of this middleware's counters.
+func (m *CaptchaDetectorMiddleware) Stats() interface{} {
+	return CaptchaDetectorStats{
+		Invocations: atomic.LoadInt64(&m.invocations),
+		Detections:  atomic.LoadInt64(&m.detections),
+	}
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+	"sync"
+)
+
+// CookieJarMiddleware keeps a separate http.CookieJar per target host so
+// sessions against one domain never leak cookies into requests to another -
+// relevant once a single chain is shared across scrapers hitting multiple
+// sites.
+type CookieJarMiddleware struct {
+	mu    sync.Mutex
+	jars  map[string]http.CookieJar
+}
+
+// NewCookieJarMiddleware builds an empty per-domain CookieJarMiddleware.
+func NewCookieJarMiddleware() *CookieJarMiddleware {
+	return &CookieJarMiddleware{jars: make(map[string]http.CookieJar)}
+}
+
+func (m *CookieJarMiddleware) Name() string { return "cookie_jar" }
+
+func (m *CookieJarMiddleware) Process(req *http.Request, next Handler) (*http.Response, error) {
+	jar := m.jarFor(req.URL.Host)
+
+	for _, cookie := range jar.Cookies(req.URL) {
+		req.AddCookie(cookie)
+	}
+
+	resp, err := next(req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+
+	if cookies := resp.Cookies(); len(cookies) > 0 {
+		jar.SetCookies(req.URL, cookies)
+	}
+
+	return resp, nil
+}
+
+func (m *CookieJarMiddleware) jarFor(host string) http.CookieJar {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if jar, ok := m.jars[host]; ok {
+		return jar
+	}
+
+	// cookiejar.New never actually errors for a nil PublicSuffixList.
+	jar, _ := cookiejar.New(nil)
+	m.jars[host] = jar
+	return jar
+}
@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DedupStats holds invocation counters for DedupMiddleware, read via Stats().
+type DedupStats struct {
+	Invocations int64
+	Hits        int64
+}
+
+type dedupEntry struct {
+	resp    cachedResponse
+	expires time.Time
+}
+
+// cachedResponse is a serializable snapshot of an *http.Response body plus
+// the fields callers typically read, so a cache hit can be replayed without
+// holding the original (already-closed) response open.
+type cachedResponse struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+}
+
+// DedupMiddleware suppresses repeat requests for the same URL+body within a
+// TTL window, returning the cached response instead of re-issuing the call.
+// Useful when a scheduler retry and a scraper-level retry race and both end
+// up requesting the same page.
+type DedupMiddleware struct {
+	ttl time.Duration
+
+	mu          sync.Mutex
+	cache       map[string]dedupEntry
+	invocations int64
+	hits        int64
+}
+
+// NewDedupMiddleware builds a DedupMiddleware caching identical requests for ttl.
+func NewDedupMiddleware(ttl time.Duration) *DedupMiddleware {
+	return &DedupMiddleware{ttl: ttl, cache: make(map[string]dedupEntry)}
+}
+
+func (m *DedupMiddleware) Name() string { return "dedup" }
+
+func (m *DedupMiddleware) Process(req *http.Request, next Handler) (*http.Response, error) {
+	m.invocations++
+
+	key, body, err := m.requestKey(req)
+	if err != nil {
+		return next(req)
+	}
+	if body != nil {
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	m.mu.Lock()
+	if entry, ok := m.cache[key]; ok && time.Now().Before(entry.expires) {
+		m.hits++
+		m.mu.Unlock()
+		return replayResponse(entry.resp), nil
+	}
+	m.mu.Unlock()
+
+	resp, err := next(req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+
+	snapshot, replay, snapErr := snapshotResponse(resp)
+	if snapErr != nil {
+		return resp, nil
+	}
+
+	m.mu.Lock()
+	m.cache[key] = dedupEntry{resp: snapshot, expires: time.Now().Add(m.ttl)}
+	m.mu.Unlock()
+
+	return replay, nil
+}
+
+func (m *DedupMiddleware) requestKey(req *http.Request) (string, []byte, error) {
+	hasher := sha256.New()
+	hasher.Write([]byte(req.Method))
+	hasher.Write([]byte(req.URL.String()))
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		if err != nil {
+			return "", nil, err
+		}
+		hasher.Write(body)
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil)), body, nil
+}
+
+func snapshotResponse(resp *http.Response) (cachedResponse, *http.Response, error) {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return cachedResponse{}, nil, err
+	}
+
+	snapshot := cachedResponse{statusCode: resp.StatusCode, header: resp.Header.Clone(), body: body}
+	return snapshot, replayResponse(snapshot), nil
+}
+
+func replayResponse(snapshot cachedResponse) *http.Response {
+	return &http.Response{
+		StatusCode: snapshot.statusCode,
+		Header:     snapshot.header.Clone(),
+		Body:       io.NopCloser(bytes.NewReader(snapshot.body)),
+	}
+}
+
+// Stats returns a snapshot of this middleware's counters.
+func (m *DedupMiddleware) Stats() interface{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return DedupStats{Invocations: m.invocations, Hits: m.hits}
+}
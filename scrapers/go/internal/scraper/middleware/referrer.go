@@ -0,0 +1,29 @@
+package middleware
+
+import "net/http"
+
+// ReferrerMiddleware sets the Referer header from the request's
+// RequestContext.Referrers chain (if attached) and, on success, appends the
+// request's own URL so the next hop in the same task can continue the
+// chain. Scrapers that follow pagination or detail links build up a
+// realistic referrer trail this way instead of every request looking like
+// it arrived from nowhere.
+type ReferrerMiddleware struct{}
+
+// NewReferrerMiddleware builds a ReferrerMiddleware.
+func NewReferrerMiddleware() *ReferrerMiddleware { return &ReferrerMiddleware{} }
+
+func (m *ReferrerMiddleware) Name() string { return "referrer" }
+
+func (m *ReferrerMiddleware) Process(req *http.Request, next Handler) (*http.Response, error) {
+	rc, ok := RequestContextFromContext(req.Context())
+	if ok && len(rc.Referrers) > 0 && req.Header.Get("Referer") == "" {
+		req.Header.Set("Referer", rc.Referrers[len(rc.Referrers)-1])
+	}
+
+	resp, err := next(req)
+	if err == nil && ok {
+		rc.Referrers = append(rc.Referrers, req.URL.String())
+	}
+	return resp, err
+}
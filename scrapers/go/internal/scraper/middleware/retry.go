@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// RetryStats holds invocation counters for RetryMiddleware, read via Stats().
+type RetryStats struct {
+	Invocations int64
+	Retries     int64
+	Exhausted   int64
+}
+
+// RetryMiddleware retries a request with exponential backoff whenever the
+// rest of the chain returns an error (a network error, or a
+// scraper.ScrapingError from an inner middleware whatever its Retryable
+// flag) or a 429/5xx response. A request is only retried if its method is
+// naturally idempotent (GET/HEAD/OPTIONS) or the attached RequestContext
+// explicitly marks it Idempotent - e.g. a read-only POST search endpoint.
+type RetryMiddleware struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+
+	invocations int64
+	retries     int64
+	exhausted   int64
+}
+
+// NewRetryMiddleware builds a RetryMiddleware with maxRetries attempts
+// beyond the first, backing off baseDelay*2^attempt capped at maxDelay.
+func NewRetryMiddleware(maxRetries int, baseDelay, maxDelay time.Duration) *RetryMiddleware {
+	return &RetryMiddleware{MaxRetries: maxRetries, BaseDelay: baseDelay, MaxDelay: maxDelay}
+}
+
+func (m *RetryMiddleware) Name() string { return "retry" }
+
+func (m *RetryMiddleware) Process(req *http.Request, next Handler) (*http.Response, error) {
+	atomic.AddInt64(&m.invocations, 1)
+
+	if !m.isRetryable(req) {
+		return next(req)
+	}
+
+	var lastResp *http.Response
+	var lastErr error
+
+	for attempt := 0; attempt <= m.MaxRetries; attempt++ {
+		if rc, ok := RequestContextFromContext(req.Context()); ok {
+			rc.Attempt = attempt + 1
+		}
+
+		resp, err := next(req)
+		if err == nil && !shouldRetryResponse(resp) {
+			return resp, nil
+		}
+
+		lastResp, lastErr = resp, err
+		if attempt == m.MaxRetries {
+			break
+		}
+
+		atomic.AddInt64(&m.retries, 1)
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		select {
+		case <-time.After(m.backoff(attempt)):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+
+	atomic.AddInt64(&m.exhausted, 1)
+	return lastResp, lastErr
+}
+
+func (m *RetryMiddleware) backoff(attempt int) time.Duration {
+	delay := time.Duration(float64(m.BaseDelay) * math.Pow(2, float64(attempt)))
+	if delay > m.MaxDelay {
+		delay = m.MaxDelay
+	}
+	return delay
+}
+
+func (m *RetryMiddleware) isRetryable(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	if rc, ok := RequestContextFromContext(req.Context()); ok {
+		return rc.Idempotent
+	}
+	return false
+}
+
+func shouldRetryResponse(resp *http.Response) bool {
+	if resp == nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// Stats returns a snapshot of this middleware's counters.
+func (m *RetryMiddleware) Stats() interface{} {
+	return RetryStats{
+		Invocations: atomic.LoadInt64(&m.invocations),
+		Retries:     atomic.LoadInt64(&m.retries),
+		Exhausted:   atomic.LoadInt64(&m.exhausted),
+	}
+}
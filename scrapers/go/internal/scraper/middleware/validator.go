@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/jobspy/scrapers/internal/scraper"
+)
+
+// ValidationMiddleware rejects responses that don't meet basic sanity
+// checks (empty body, unexpected content type) before they reach
+// higher-level parsing, surfacing a scraper.ScrapingError instead of a
+// confusing downstream JSON/HTML parse failure.
+type ValidationMiddleware struct {
+	// ExpectedContentType, when non-empty, must be a prefix of the
+	// response's Content-Type header.
+	ExpectedContentType string
+}
+
+// NewValidationMiddleware builds a ValidationMiddleware expecting
+// expectedContentType (pass "" to skip the content-type check).
+func NewValidationMiddleware(expectedContentType string) *ValidationMiddleware {
+	return &ValidationMiddleware{ExpectedContentType: expectedContentType}
+}
+
+func (m *ValidationMiddleware) Name() string { return "validator" }
+
+func (m *ValidationMiddleware) Process(req *http.Request, next Handler) (*http.Response, error) {
+	resp, err := next(req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+
+	if resp.ContentLength == 0 {
+		return resp, scraper.ScrapingError{
+			Type:       scraper.ErrorTypeParsing,
+			Message:    "empty response body",
+			URL:        req.URL.String(),
+			StatusCode: resp.StatusCode,
+			Retryable:  true,
+		}
+	}
+
+	if m.ExpectedContentType != "" {
+		ct := resp.Header.Get("Content-Type")
+		if len(ct) < len(m.ExpectedContentType) || ct[:len(m.ExpectedContentType)] != m.ExpectedContentType {
+			return resp, scraper.ScrapingError{
+				Type:       scraper.ErrorTypeParsing,
+				Message:    "unexpected content type: " + ct,
+				URL:        req.URL.String(),
+				StatusCode: resp.StatusCode,
+				Retryable:  false,
+			}
+		}
+	}
+
+	return resp, nil
+}
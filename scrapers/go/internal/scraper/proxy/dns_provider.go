@@ -0,0 +1,49 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"net"
+)
+
+// DNSProvider reports proxies by resolving a DNS SRV record, e.g. as
+// published by a service mesh or a proxy-fleet's own DNS-SD setup. SRV
+// already carries a weight per target, which maps directly onto
+// Entry.Weight.
+type DNSProvider struct {
+	resolver *net.Resolver
+	service  string
+	proto    string
+	name     string
+}
+
+// NewDNSProvider creates a DNSProvider for the SRV record
+// _service._proto.name, e.g. service="proxy", proto="tcp",
+// name="proxies.internal".
+func NewDNSProvider(service, proto, name string) *DNSProvider {
+	return &DNSProvider{resolver: net.DefaultResolver, service: service, proto: proto, name: name}
+}
+
+// Proxies resolves the SRV record and reports one Entry per target.
+func (d *DNSProvider) Proxies(ctx context.Context) ([]Entry, error) {
+	_, srvs, err := d.resolver.LookupSRV(ctx, d.service, d.proto, d.name)
+	if err != nil {
+		return nil, fmt.Errorf("SRV lookup for _%s._%s.%s failed: %w", d.service, d.proto, d.name, err)
+	}
+
+	entries := make([]Entry, len(srvs))
+	for i, srv := range srvs {
+		entries[i] = Entry{
+			Address: fmt.Sprintf("%s:%d", trimTrailingDot(srv.Target), srv.Port),
+			Weight:  float64(srv.Weight),
+		}
+	}
+	return entries, nil
+}
+
+func trimTrailingDot(host string) string {
+	if len(host) > 0 && host[len(host)-1] == '.' {
+		return host[:len(host)-1]
+	}
+	return host
+}
@@ -0,0 +1,39 @@
+package proxy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// NewProvider builds a Provider from config-friendly strings: providerType
+// selects the kind ("static", "file", "dns", "http"; empty defaults to
+// "static"), and source is interpreted per kind - a file path, a
+// "service.proto.name" SRV name, or a discovery endpoint URL. staticAddrs
+// backs the "static" kind and is also the fallback when providerType is
+// empty, so existing ProxyPool-only configuration keeps working unchanged.
+func NewProvider(providerType, source string, staticAddrs []string, logger *logrus.Logger) (Provider, error) {
+	switch strings.ToLower(providerType) {
+	case "", "static":
+		return NewStaticProvider(staticAddrs), nil
+	case "file":
+		if source == "" {
+			return nil, fmt.Errorf("proxy provider type %q requires a source file path", providerType)
+		}
+		return NewFileProvider(source, logger)
+	case "dns":
+		parts := strings.SplitN(source, ".", 3)
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("proxy provider type %q requires a source of the form service.proto.name, got %q", providerType, source)
+		}
+		return NewDNSProvider(parts[0], parts[1], parts[2]), nil
+	case "http":
+		if source == "" {
+			return nil, fmt.Errorf("proxy provider type %q requires a source URL", providerType)
+		}
+		return NewHTTPProvider(source, nil), nil
+	default:
+		return nil, fmt.Errorf("unknown proxy provider type %q", providerType)
+	}
+}
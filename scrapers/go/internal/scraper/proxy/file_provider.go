@@ -0,0 +1,105 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/sirupsen/logrus"
+)
+
+// FileProvider reads a JSON array of Entry from a file and keeps it fresh
+// via fsnotify, mirroring config.Watcher's handling of an editor's
+// rename-based save. Proxies always returns the last-loaded cache, so a
+// caller polling it on an interval (see Pool.Run) gets the latest file
+// contents at each poll tick without re-reading the file itself.
+type FileProvider struct {
+	path    string
+	logger  *logrus.Logger
+	watcher *fsnotify.Watcher
+
+	mu    sync.RWMutex
+	cache []Entry
+}
+
+// NewFileProvider creates a FileProvider watching path, doing an initial
+// synchronous load so the first Proxies call has data without waiting for
+// an fsnotify event.
+func NewFileProvider(path string, logger *logrus.Logger) (*FileProvider, error) {
+	fp := &FileProvider{path: path, logger: logger}
+	if err := fp.load(); err != nil {
+		return nil, fmt.Errorf("failed to load initial proxy file %s: %w", path, err)
+	}
+
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create fsnotify watcher: %w", err)
+	}
+	if err := fsw.Add(path); err != nil {
+		fsw.Close()
+		return nil, fmt.Errorf("failed to watch proxy file %s: %w", path, err)
+	}
+	fp.watcher = fsw
+
+	go fp.run()
+	return fp, nil
+}
+
+func (fp *FileProvider) run() {
+	for {
+		select {
+		case event, ok := <-fp.watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&(fsnotify.Remove|fsnotify.Rename) != 0 {
+				if err := fp.watcher.Add(fp.path); err != nil {
+					fp.logger.WithError(err).Warn("Failed to re-add proxy file watch after rename/remove")
+				}
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Remove|fsnotify.Rename) == 0 {
+				continue
+			}
+			if err := fp.load(); err != nil {
+				fp.logger.WithError(err).Warn("Failed to reload proxy file, keeping previous list")
+			}
+		case err, ok := <-fp.watcher.Errors:
+			if !ok {
+				return
+			}
+			fp.logger.WithError(err).Warn("Proxy file watcher error")
+		}
+	}
+}
+
+func (fp *FileProvider) load() error {
+	data, err := os.ReadFile(fp.path)
+	if err != nil {
+		return err
+	}
+
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+
+	fp.mu.Lock()
+	fp.cache = entries
+	fp.mu.Unlock()
+	return nil
+}
+
+// Proxies returns the last-loaded proxy list.
+func (fp *FileProvider) Proxies(ctx context.Context) ([]Entry, error) {
+	fp.mu.RLock()
+	defer fp.mu.RUnlock()
+	return fp.cache, nil
+}
+
+// Stop releases the underlying fsnotify handle.
+func (fp *FileProvider) Stop() {
+	fp.watcher.Close()
+}
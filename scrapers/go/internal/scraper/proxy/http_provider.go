@@ -0,0 +1,50 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// HTTPProvider reports proxies from an HTTP endpoint returning a JSON array
+// of Entry, borrowing Prometheus's HTTP service-discovery target-sync
+// model: the endpoint is polled (by Pool.Run) and whatever it returns each
+// time is the authoritative current set.
+type HTTPProvider struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPProvider creates an HTTPProvider for url. A nil client uses
+// http.DefaultClient.
+func NewHTTPProvider(url string, client *http.Client) *HTTPProvider {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTPProvider{url: url, client: client}
+}
+
+// Proxies fetches and decodes the current entry list from the endpoint.
+func (h *HTTPProvider) Proxies(ctx context.Context) ([]Entry, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build proxy discovery request: %w", err)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("proxy discovery request to %s failed: %w", h.url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("proxy discovery endpoint %s returned status %d", h.url, resp.StatusCode)
+	}
+
+	var entries []Entry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("failed to decode proxy discovery response from %s: %w", h.url, err)
+	}
+	return entries, nil
+}
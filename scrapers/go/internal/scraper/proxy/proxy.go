@@ -0,0 +1,407 @@
+// Package proxy implements a service-discovery-style dynamic proxy pool:
+// a Provider periodically reports the current set of proxies (from a
+// static list, a watched file, DNS SRV records, or an HTTP endpoint), and
+// a Pool reconciles that set against what it's currently using - probing
+// new proxies before handing them out, draining removed ones, and scoring
+// every proxy on a rolling success/latency basis so rotation can prefer
+// proxies that are actually working. Pool implements
+// scraper.ProxyManager, so it drops into anything written against that
+// interface.
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jobspy/scrapers/internal/scraper"
+)
+
+// Entry is one proxy as reported by a Provider.
+type Entry struct {
+	Address string        `json:"address"`
+	Weight  float64       `json:"weight,omitempty"`
+	Region  string        `json:"region,omitempty"`
+	Latency time.Duration `json:"latency,omitempty"`
+}
+
+// Provider reports the current set of proxies that should be in the pool.
+// Pool.Run polls it on an interval and reconciles the result, so a
+// Provider only needs to answer "what's current right now" - anything
+// push-based (a watched file, a long-lived stream) should keep its own
+// cache fresh in the background and have Proxies just return it.
+type Provider interface {
+	Proxies(ctx context.Context) ([]Entry, error)
+}
+
+// StaticProvider reports a fixed set of proxies, equivalent to the plain
+// ProxyPool []string configuration this package replaces as the default
+// source.
+type StaticProvider struct {
+	entries []Entry
+}
+
+// NewStaticProvider wraps a plain address list as a Provider, each address
+// getting equal weight.
+func NewStaticProvider(addresses []string) *StaticProvider {
+	entries := make([]Entry, len(addresses))
+	for i, addr := range addresses {
+		entries[i] = Entry{Address: addr, Weight: 1}
+	}
+	return &StaticProvider{entries: entries}
+}
+
+func (p *StaticProvider) Proxies(ctx context.Context) ([]Entry, error) {
+	return p.entries, nil
+}
+
+// RotationStrategy selects how Pool.GetProxy picks among healthy proxies.
+type RotationStrategy string
+
+const (
+	RotationRoundRobin          RotationStrategy = "round_robin"
+	RotationWeighted            RotationStrategy = "weighted"
+	RotationStickyPerHost       RotationStrategy = "sticky_per_host"
+	RotationLeastRecentlyFailed RotationStrategy = "least_recently_failed"
+)
+
+// proxyState is everything Pool tracks about one proxy between reconciles.
+type proxyState struct {
+	entry   Entry
+	stats   scraper.ProxyStats
+	drained bool
+}
+
+// Pool reconciles a Provider's reported proxy set against live use and
+// implements scraper.ProxyManager on top of the result. The zero value is
+// not usable; construct with NewPool.
+type Pool struct {
+	strategy     RotationStrategy
+	canaryURL    string
+	probeTimeout time.Duration
+
+	mu      sync.Mutex
+	states  map[string]*proxyState
+	order   []string // stable iteration order for round-robin and weighted selection
+	rrIndex int
+	sticky  string // last proxy handed out, reused by RotationStickyPerHost
+}
+
+// NewPool creates a Pool that selects proxies per strategy. canaryURL, if
+// set, is HEAD-probed through a newly reported proxy before Reconcile marks
+// it healthy; an empty canaryURL skips probing and trusts the provider
+// (useful in tests or when the provider already only reports known-good
+// proxies, e.g. an HTTPProvider backed by an operator-curated list).
+func NewPool(strategy RotationStrategy, canaryURL string) *Pool {
+	return &Pool{
+		strategy:     strategy,
+		canaryURL:    canaryURL,
+		probeTimeout: 10 * time.Second,
+		states:       make(map[string]*proxyState),
+	}
+}
+
+// Run polls provider every interval and reconciles the result, until ctx is
+// canceled. It should be run in its own goroutine.
+func (p *Pool) Run(ctx context.Context, provider Provider, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	p.poll(ctx, provider)
+	for {
+		select {
+		case <-ticker.C:
+			p.poll(ctx, provider)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (p *Pool) poll(ctx context.Context, provider Provider) {
+	entries, err := provider.Proxies(ctx)
+	if err != nil {
+		return
+	}
+	p.Reconcile(ctx, entries)
+}
+
+// Reconcile brings the pool's tracked proxies in line with entries: a new
+// address is health-probed before being added, and a tracked address no
+// longer present is drained (excluded from selection, but its stats are
+// kept until the next Reconcile that still omits it, in case it reappears
+// - then they're dropped for good).
+func (p *Pool) Reconcile(ctx context.Context, entries []Entry) {
+	seen := make(map[string]bool, len(entries))
+
+	for _, entry := range entries {
+		seen[entry.Address] = true
+
+		p.mu.Lock()
+		state, exists := p.states[entry.Address]
+		p.mu.Unlock()
+
+		if exists {
+			p.mu.Lock()
+			state.entry = entry
+			state.drained = false
+			p.mu.Unlock()
+			continue
+		}
+
+		healthy := p.probe(ctx, entry.Address)
+		p.mu.Lock()
+		p.states[entry.Address] = &proxyState{
+			entry: entry,
+			stats: scraper.ProxyStats{URL: entry.Address, IsHealthy: healthy},
+		}
+		p.order = append(p.order, entry.Address)
+		p.mu.Unlock()
+	}
+
+	p.mu.Lock()
+	for addr, state := range p.states {
+		if !seen[addr] {
+			if state.drained {
+				delete(p.states, addr)
+				p.order = removeAddr(p.order, addr)
+			} else {
+				state.drained = true
+			}
+		}
+	}
+	p.mu.Unlock()
+}
+
+// probe HEADs canaryURL to decide whether a newly reported proxy is worth
+// adding as healthy. A proxy that fails the probe is still added (so a
+// provider that's momentarily ahead of reality doesn't lose the entry
+// outright), just marked unhealthy until it earns a success.
+func (p *Pool) probe(ctx context.Context, address string) bool {
+	if p.canaryURL == "" {
+		return true
+	}
+
+	proxyURL, err := parseProxyURL(address)
+	if err != nil {
+		return false
+	}
+
+	client := &http.Client{
+		Timeout:   p.probeTimeout,
+		Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, p.canaryURL, nil)
+	if err != nil {
+		return false
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode < 500
+}
+
+// GetProxy selects a proxy per the configured RotationStrategy among
+// currently healthy, non-drained proxies.
+func (p *Pool) GetProxy() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	healthy := p.healthyAddrsLocked()
+	if len(healthy) == 0 {
+		return "", fmt.Errorf("no healthy proxies available")
+	}
+
+	var chosen string
+	switch p.strategy {
+	case RotationWeighted:
+		chosen = p.pickWeightedLocked(healthy)
+	case RotationStickyPerHost:
+		// This client only ever calls one upstream host, so "per host"
+		// degenerates to "the same proxy every time until it's removed
+		// or goes unhealthy" - there's no second host in this codebase
+		// for the sticky mapping to actually key on.
+		if p.sticky != "" && contains(healthy, p.sticky) {
+			chosen = p.sticky
+		} else {
+			chosen = healthy[rand.Intn(len(healthy))]
+			p.sticky = chosen
+		}
+	case RotationLeastRecentlyFailed:
+		chosen = p.pickLeastRecentlyFailedLocked(healthy)
+	case RotationRoundRobin, "":
+		fallthrough
+	default:
+		chosen = healthy[p.rrIndex%len(healthy)]
+		p.rrIndex++
+	}
+
+	state := p.states[chosen]
+	state.stats.TotalRequests++
+	state.stats.LastUsed = time.Now()
+	return chosen, nil
+}
+
+func (p *Pool) pickWeightedLocked(healthy []string) string {
+	var total float64
+	for _, addr := range healthy {
+		w := p.states[addr].entry.Weight
+		if w <= 0 {
+			w = 1
+		}
+		total += w
+	}
+
+	target := rand.Float64() * total
+	var cumulative float64
+	for _, addr := range healthy {
+		w := p.states[addr].entry.Weight
+		if w <= 0 {
+			w = 1
+		}
+		cumulative += w
+		if target <= cumulative {
+			return addr
+		}
+	}
+	return healthy[len(healthy)-1]
+}
+
+func (p *Pool) pickLeastRecentlyFailedLocked(healthy []string) string {
+	best := healthy[0]
+	for _, addr := range healthy[1:] {
+		if p.states[addr].stats.LastFailure.Before(p.states[best].stats.LastFailure) {
+			best = addr
+		}
+	}
+	return best
+}
+
+func (p *Pool) healthyAddrsLocked() []string {
+	var healthy []string
+	for _, addr := range p.order {
+		state, ok := p.states[addr]
+		if !ok || state.drained || !state.stats.IsHealthy {
+			continue
+		}
+		healthy = append(healthy, addr)
+	}
+	return healthy
+}
+
+// MarkProxyFailed records a failed use of proxy, marking it unhealthy once
+// its total failures outnumber its total successes by 3 or more. The counts
+// are cumulative, so a success does not reset the failure count.
+func (p *Pool) MarkProxyFailed(address string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	state, ok := p.states[address]
+	if !ok {
+		return
+	}
+	state.stats.FailureCount++
+	state.stats.LastFailure = time.Now()
+	state.stats.SuccessRate = successRate(state.stats)
+	if state.stats.FailureCount-state.stats.SuccessCount >= 3 {
+		state.stats.IsHealthy = false
+	}
+}
+
+// MarkProxySuccess records a successful use of proxy, restoring it to
+// healthy if it had been marked down.
+func (p *Pool) MarkProxySuccess(address string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	state, ok := p.states[address]
+	if !ok {
+		return
+	}
+	state.stats.SuccessCount++
+	state.stats.LastSuccess = time.Now()
+	state.stats.SuccessRate = successRate(state.stats)
+	state.stats.IsHealthy = true
+}
+
+// GetHealthyProxies returns the addresses currently eligible for
+// GetProxy, in pool order.
+func (p *Pool) GetHealthyProxies() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.healthyAddrsLocked()
+}
+
+// GetProxyStats returns a snapshot of every tracked proxy's stats,
+// including drained ones still waiting to age out.
+func (p *Pool) GetProxyStats() map[string]scraper.ProxyStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := make(map[string]scraper.ProxyStats, len(p.states))
+	for addr, state := range p.states {
+		stats[addr] = state.stats
+	}
+	return stats
+}
+
+// ReloadPool replaces the pool's source with a plain address list,
+// equivalent to pointing it at a new StaticProvider. Existing proxies not
+// in the new list are drained exactly as Reconcile would; this is the
+// method a config hot-reload (see worker.Orchestrator.applyConfigReload)
+// calls when ProxyPool changes but a dynamic ProxyProviderType isn't
+// configured.
+func (p *Pool) ReloadPool(proxies []string) error {
+	entries := make([]Entry, len(proxies))
+	for i, addr := range proxies {
+		entries[i] = Entry{Address: addr, Weight: 1}
+	}
+	p.Reconcile(context.Background(), entries)
+	return nil
+}
+
+func successRate(stats scraper.ProxyStats) float64 {
+	total := stats.SuccessCount + stats.FailureCount
+	if total == 0 {
+		return 0
+	}
+	return float64(stats.SuccessCount) / float64(total)
+}
+
+func contains(addrs []string, addr string) bool {
+	for _, a := range addrs {
+		if a == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// parseProxyURL parses address as a proxy URL, defaulting to an http://
+// scheme when none is given (a bare "host:port" entry, the common case for
+// both the legacy ProxyPool list and DNS SRV results).
+func parseProxyURL(address string) (*url.URL, error) {
+	if !strings.Contains(address, "://") {
+		address = "http://" + address
+	}
+	return url.Parse(address)
+}
+
+func removeAddr(addrs []string, addr string) []string {
+	for i, a := range addrs {
+		if a == addr {
+			return append(addrs[:i], addrs[i+1:]...)
+		}
+	}
+	return addrs
+}
@@ -0,0 +1,54 @@
+package scraper
+
+import (
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/jobspy/scrapers/internal/ratelimit"
+)
+
+// Constructor builds a Scraper backend instance. registry is the shared
+// SiteRegistry used for cross-worker per-site staggering and may be nil.
+// limiter is the shared ratelimit.AdaptiveLimiter backends should Wait/
+// ReportOutcome against instead of rolling their own rate limiting, and may
+// also be nil (e.g. in tests that construct a backend directly).
+type Constructor func(config ScraperConfig, logger *logrus.Logger, registry *SiteRegistry, limiter *ratelimit.AdaptiveLimiter) (Scraper, error)
+
+var (
+	registryLock sync.RWMutex
+	backends     = make(map[string]Constructor)
+)
+
+// Register makes a scraper backend constructor available under name, e.g.
+// "jobspy". Backend packages call this from an init() so that merely
+// importing them (even blank-importing, as main.go does) is enough to make
+// them selectable via ScraperConfig.Backend. Re-registering an existing name
+// replaces it, matching how database/sql drivers and similar Go registries
+// behave.
+func Register(name string, constructor Constructor) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	backends[name] = constructor
+}
+
+// Lookup returns the constructor registered under name, if any.
+func Lookup(name string) (Constructor, bool) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	constructor, ok := backends[name]
+	return constructor, ok
+}
+
+// RegisteredBackends returns the names of every currently registered
+// backend, for diagnostics (e.g. logging what's available at startup).
+func RegisteredBackends() []string {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	return names
+}
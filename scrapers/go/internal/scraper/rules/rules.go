@@ -0,0 +1,213 @@
+// Package rules implements a small, file-based extraction-rule engine: an
+// operator drops a JSON or YAML "rule" file into a directory (see
+// scraper.ScraperConfig.ScrapeRulesDir), and a scraper applies the rule
+// matching a job's site to pull extra structured fields (salary bands,
+// benefits, remote flags, ...) out of free text without a binary rebuild.
+//
+// Only regex selectors are executed in this build. CSS and XPath selectors
+// are accepted and round-tripped (so a rule file written against a future
+// HTML-fetching backend still loads cleanly), but there is currently no
+// HTML-fetching/parsing pipeline anywhere in this repository - every
+// registered scraper backend talks to a JSON API, not raw HTML - so there is
+// nothing for a CSS/XPath selector to run against yet. Extract reports this
+// explicitly via an error per declared-but-unsupported selector rather than
+// silently skipping it.
+package rules
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SelectorType names the selector language a Selector.Expr is written in.
+type SelectorType string
+
+const (
+	SelectorRegex SelectorType = "regex"
+	SelectorCSS   SelectorType = "css"
+	SelectorXPath SelectorType = "xpath"
+)
+
+// Selector is one field extraction within a Rule: Expr is interpreted
+// according to Type. Group, if non-zero, selects a capture group within a
+// regex match instead of the whole match (group 0).
+type Selector struct {
+	Type  SelectorType `json:"type" yaml:"type"`
+	Expr  string       `json:"expr" yaml:"expr"`
+	Group int          `json:"group,omitempty" yaml:"group,omitempty"`
+}
+
+// Rule targets one site + page type (e.g. "indeed" job cards, "linkedin"
+// listings, "glassdoor" details) and declares the fields it extracts.
+// TargetField optionally maps an extracted field name onto the name it
+// should take on protocol.JobData (e.g. "comp_range" -> "SalaryMin"); a
+// field absent from TargetField is reported under its own name only.
+type Rule struct {
+	Name        string              `json:"name" yaml:"name"`
+	Version     string              `json:"version" yaml:"version"`
+	Site        string              `json:"site" yaml:"site"`
+	PageType    string              `json:"page_type" yaml:"page_type"`
+	Selectors   map[string]Selector `json:"selectors" yaml:"selectors"`
+	TargetField map[string]string   `json:"target_field,omitempty" yaml:"target_field,omitempty"`
+
+	path string
+}
+
+// Path returns the rule file Rule was loaded from, for diagnostics.
+func (r *Rule) Path() string {
+	return r.path
+}
+
+// Extract runs every regex selector in r against text, returning the
+// extracted fields keyed by TargetField's mapping where present (otherwise
+// the selector's own name). A selector that fails to match contributes no
+// entry; a CSS/XPath selector contributes an error instead of silently
+// doing nothing, since neither is actually executed in this build.
+func (r *Rule) Extract(text string) (map[string]string, []error) {
+	fields := make(map[string]string)
+	var errs []error
+
+	for name, sel := range r.Selectors {
+		outName := name
+		if mapped, ok := r.TargetField[name]; ok {
+			outName = mapped
+		}
+
+		switch sel.Type {
+		case SelectorRegex, "":
+			re, err := regexp.Compile(sel.Expr)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("rule %s: selector %q: invalid regex %q: %w", r.Name, name, sel.Expr, err))
+				continue
+			}
+			match := re.FindStringSubmatch(text)
+			if match == nil || sel.Group >= len(match) {
+				continue
+			}
+			fields[outName] = match[sel.Group]
+		case SelectorCSS, SelectorXPath:
+			errs = append(errs, fmt.Errorf("rule %s: selector %q is declared as %s but not executed: this build has no HTML-fetching/parsing pipeline to run it against", r.Name, name, sel.Type))
+		default:
+			errs = append(errs, fmt.Errorf("rule %s: selector %q has unknown type %q", r.Name, name, sel.Type))
+		}
+	}
+
+	return fields, errs
+}
+
+// key is the lookup key RuleSet indexes rules under: site and page type,
+// lower-cased so rule files don't need to match case exactly.
+func key(site, pageType string) string {
+	return strings.ToLower(site) + "/" + strings.ToLower(pageType)
+}
+
+// RuleSet is every rule successfully loaded from a directory, indexed for
+// lookup by site + page type.
+type RuleSet struct {
+	Dir   string
+	Rules []*Rule
+	byKey map[string]*Rule
+}
+
+// Match returns the rule targeting site + pageType, if one was loaded.
+func (rs *RuleSet) Match(site, pageType string) (*Rule, bool) {
+	if rs == nil {
+		return nil, false
+	}
+	r, ok := rs.byKey[key(site, pageType)]
+	return r, ok
+}
+
+// Version returns a short, stable fingerprint over every rule currently
+// loaded (site/page_type@version, sorted), so a caller can tag something
+// with "which generation of rules it ran under" - e.g. protocol.TaskHash -
+// without caring about individual rule contents. An empty or nil RuleSet
+// returns "none", so a worker with rule-based extraction disabled still
+// produces a stable, human-readable value.
+func (rs *RuleSet) Version() string {
+	if rs == nil || len(rs.Rules) == 0 {
+		return "none"
+	}
+
+	parts := make([]string, len(rs.Rules))
+	for i, r := range rs.Rules {
+		parts[i] = key(r.Site, r.PageType) + "@" + r.Version
+	}
+	sort.Strings(parts)
+
+	sum := sha256.Sum256([]byte(strings.Join(parts, ",")))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// LoadRules reads every .json/.yaml/.yml file in dir as a Rule. A single
+// malformed file is collected as an error and skipped rather than failing
+// the whole load, so one broken selector file doesn't take down every other
+// site's rules.
+func LoadRules(dir string) (*RuleSet, []error) {
+	rs := &RuleSet{Dir: dir, byKey: make(map[string]*Rule)}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return rs, []error{fmt.Errorf("failed to read rules directory %s: %w", dir, err)}
+	}
+
+	var errs []error
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if ext != ".json" && ext != ".yaml" && ext != ".yml" {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		rule, err := loadRuleFile(path, ext)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("rule file %s: %w", path, err))
+			continue
+		}
+
+		rs.Rules = append(rs.Rules, rule)
+		rs.byKey[key(rule.Site, rule.PageType)] = rule
+	}
+
+	return rs, errs
+}
+
+func loadRuleFile(path, ext string) (*Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var rule Rule
+	if ext == ".json" {
+		if err := json.Unmarshal(data, &rule); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON: %w", err)
+		}
+	} else {
+		if err := yaml.Unmarshal(data, &rule); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML: %w", err)
+		}
+	}
+
+	if rule.Name == "" {
+		return nil, fmt.Errorf("rule is missing a name")
+	}
+	if rule.Site == "" {
+		return nil, fmt.Errorf("rule %q is missing a site", rule.Name)
+	}
+
+	rule.path = path
+	return &rule, nil
+}
@@ -0,0 +1,105 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/sirupsen/logrus"
+)
+
+// RuleWatcher observes a rules directory and pushes a freshly loaded
+// RuleSet through Updates() whenever a file in it changes. It mirrors
+// config.Watcher's fsnotify handling (re-adding the watch after a
+// rename/remove, since an editor saving via temp-file rename would
+// otherwise drop it), but watches a directory of many files instead of one.
+type RuleWatcher struct {
+	logger  *logrus.Logger
+	watcher *fsnotify.Watcher
+	dir     string
+	updates chan *RuleSet
+	stopCh  chan struct{}
+}
+
+// NewRuleWatcher creates a RuleWatcher for dir.
+func NewRuleWatcher(dir string, logger *logrus.Logger) (*RuleWatcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create fsnotify watcher: %w", err)
+	}
+
+	if err := fsw.Add(dir); err != nil {
+		fsw.Close()
+		return nil, fmt.Errorf("failed to watch rules directory %s: %w", dir, err)
+	}
+
+	return &RuleWatcher{
+		logger:  logger,
+		watcher: fsw,
+		dir:     dir,
+		updates: make(chan *RuleSet, 1),
+		stopCh:  make(chan struct{}),
+	}, nil
+}
+
+// Updates returns the channel freshly loaded RuleSets are delivered on. The
+// channel is buffered with size 1 and the newest reload wins if the
+// consumer hasn't drained the previous one yet.
+func (w *RuleWatcher) Updates() <-chan *RuleSet {
+	return w.updates
+}
+
+// Run processes fsnotify events until Stop is called. It should be run in
+// its own goroutine.
+func (w *RuleWatcher) Run() {
+	for {
+		select {
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			w.handleEvent(event)
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			w.logger.WithError(err).Warn("Rules watcher error")
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+// Stop stops the watcher and releases the underlying fsnotify handle.
+func (w *RuleWatcher) Stop() {
+	close(w.stopCh)
+	w.watcher.Close()
+}
+
+func (w *RuleWatcher) handleEvent(event fsnotify.Event) {
+	if event.Op&(fsnotify.Remove|fsnotify.Rename) != 0 {
+		if err := w.watcher.Add(w.dir); err != nil {
+			w.logger.WithError(err).Warn("Failed to re-add rules directory watch after rename/remove")
+		}
+	}
+
+	if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Remove|fsnotify.Rename) == 0 {
+		return
+	}
+
+	ruleSet, errs := LoadRules(w.dir)
+	for _, err := range errs {
+		w.logger.WithError(err).Warn("Failed to load a rule file during hot-reload")
+	}
+
+	select {
+	case w.updates <- ruleSet:
+	default:
+		select {
+		case <-w.updates:
+		default:
+		}
+		w.updates <- ruleSet
+	}
+
+	w.logger.WithField("rules_loaded", len(ruleSet.Rules)).Info("Scrape rules hot-reloaded")
+}
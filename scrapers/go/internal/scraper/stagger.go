@@ -0,0 +1,98 @@
+package scraper
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// defaultSiteMinGap is the minimum spacing enforced between two requests
+// against the same target site when no more specific configuration is
+// available. It mirrors the scale of the per-site RequestDelay config
+// values (1.5-3s) used elsewhere in the codebase.
+const defaultSiteMinGap = 2 * time.Second
+
+// SiteRegistry tracks the last request time per target site so concurrent
+// scrapers hitting the same domain are naturally spread out instead of
+// firing simultaneously, the same problem Prometheus solves by staggering
+// scrape targets across the polling interval.
+type SiteRegistry struct {
+	mu      sync.Mutex
+	minGap  time.Duration
+	lastRun map[string]time.Time
+}
+
+// NewSiteRegistry creates a registry enforcing at least minGap between
+// requests to the same site. A zero minGap uses defaultSiteMinGap.
+func NewSiteRegistry(minGap time.Duration) *SiteRegistry {
+	if minGap <= 0 {
+		minGap = defaultSiteMinGap
+	}
+	return &SiteRegistry{
+		minGap:  minGap,
+		lastRun: make(map[string]time.Time),
+	}
+}
+
+// Wait blocks until it is this call's turn to hit site, reserving the slot
+// before returning so a second concurrent caller waits out the full gap
+// rather than racing in right behind the first. Returns ctx.Err() if the
+// context is cancelled while waiting.
+func (r *SiteRegistry) Wait(ctx context.Context, site string) error {
+	r.mu.Lock()
+	now := time.Now()
+	wait := time.Duration(0)
+	if last, ok := r.lastRun[site]; ok {
+		if elapsed := now.Sub(last); elapsed < r.minGap {
+			wait = r.minGap - elapsed
+		}
+	}
+	r.lastRun[site] = now.Add(wait)
+	r.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// JitteredDelay returns a bounded-uniform random duration between
+// cfg.MinDelay and cfg.MaxDelay, used as a per-request pacing delay so
+// repeated calls from one worker don't land at a fixed cadence. Falls back
+// to cfg.MinDelay (possibly zero) when MaxDelay isn't greater.
+func JitteredDelay(cfg ScraperConfig) time.Duration {
+	if cfg.MaxDelay <= cfg.MinDelay {
+		return cfg.MinDelay
+	}
+	spread := cfg.MaxDelay - cfg.MinDelay
+	return cfg.MinDelay + time.Duration(rand.Int63n(int64(spread)))
+}
+
+// SleepJittered sleeps for JitteredDelay(cfg), returning early with
+// ctx.Err() if the context is cancelled first.
+func SleepJittered(ctx context.Context, cfg ScraperConfig) error {
+	delay := JitteredDelay(cfg)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
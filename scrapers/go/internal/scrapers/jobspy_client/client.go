@@ -7,24 +7,84 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
 
 	"github.com/jobspy/scrapers/internal/protocol"
+	"github.com/jobspy/scrapers/internal/ratelimit"
+	"github.com/jobspy/scrapers/internal/resilience"
+	"github.com/jobspy/scrapers/internal/salary"
 	"github.com/jobspy/scrapers/internal/scraper"
+	"github.com/jobspy/scrapers/internal/scraper/middleware"
+	"github.com/jobspy/scrapers/internal/scraper/proxy"
+	"github.com/jobspy/scrapers/internal/scraper/rules"
 )
 
+// rulePageType is the page type every JobSpy result is matched against: the
+// API only ever returns a flat list of job-card-shaped entries, never a
+// separate detail page, so there's only one page type to target rules at.
+const rulePageType = "listing"
+
+// Circuit breaker thresholds for calls to the JobSpy API: open after 5
+// consecutive failures or once more than half of calls in the trailing
+// minute fail, then allow a single probe every 30s.
+const (
+	breakerFailureThreshold   = 5
+	breakerErrorRateThreshold = 0.5
+	breakerWindow             = time.Minute
+	breakerCooldown           = 30 * time.Second
+)
+
+// defaultProxyPollInterval is used when ScraperConfig.ProxyPollInterval is
+// unset, matching config.DefaultConfig's ProxyPollInterval default.
+const defaultProxyPollInterval = 30 * time.Second
+
 // JobSpyAPIClient implements the Scraper interface by calling JobSpy API
 type JobSpyAPIClient struct {
+	configLock   sync.RWMutex
 	config       scraper.ScraperConfig
 	logger       *logrus.Logger
-	httpClient   *http.Client
+	httpClient   scraper.HTTPClientInterface
 	metrics      *scraper.ScrapingMetrics
 	healthStatus *protocol.HealthStatus
 	apiBaseURL   string
+	siteRegistry *scraper.SiteRegistry
+	breakers     *resilience.BreakerRegistry
+	fxProvider   salary.FXProvider
+
+	// limiter AIMD-throttles calls to apiBaseURL (see ratelimit.Tuple). This
+	// client calls its own JobSpy API backend rather than fetching a real
+	// job site directly, so - like siteRegistry/breakers above - it can only
+	// key the adaptive rate on apiBaseURL, not on whichever real site a
+	// given job came from; that finer-grained tuple awaits a native
+	// per-site backend. May be nil, in which case calls proceed unthrottled
+	// beyond the existing breaker/stagger/jitter.
+	limiter *ratelimit.AdaptiveLimiter
+
+	rulesLock   sync.RWMutex
+	ruleSet     *rules.RuleSet
+	ruleWatcher *rules.RuleWatcher
+
+	// proxyPool, when non-nil, tracks and health-scores the dynamic proxy
+	// pool (see scraper/proxy). This client calls its own JobSpy API
+	// backend directly rather than fetching job sites itself, so nothing
+	// here actually routes a request through a selected proxy; the pool
+	// exists so GetHealthStatus can surface real rotation/health data, and
+	// so a future native per-site backend can reuse it as-is.
+	proxyPool        *proxy.Pool
+	proxyProvider    proxy.Provider
+	proxyCancel      context.CancelFunc
 }
 
+// targetSalaryCurrency is the currency convertToProtocolJobs normalizes
+// every job's salary into, so SalaryMin/Max are comparable across jobs
+// regardless of which currency the source site reported.
+const targetSalaryCurrency = "USD"
+
 // JobSpyRequest represents the request format for JobSpy API
 type JobSpyRequest struct {
 	SiteName             []string `json:"site_name"`
@@ -49,10 +109,12 @@ type JobSpyResponse struct {
 	Cached bool                    `json:"cached"`
 }
 
-// NewJobSpyAPIClient creates a new JobSpy API client
-func NewJobSpyAPIClient(config scraper.ScraperConfig, logger *logrus.Logger) *JobSpyAPIClient {
+// NewJobSpyAPIClient creates a new JobSpy API client. registry may be nil,
+// in which case per-site staggering is disabled (each call proceeds
+// immediately). limiter may also be nil to disable adaptive rate limiting.
+func NewJobSpyAPIClient(config scraper.ScraperConfig, logger *logrus.Logger, registry *scraper.SiteRegistry, limiter *ratelimit.AdaptiveLimiter) *JobSpyAPIClient {
 	// Create HTTP client with timeouts
-	httpClient := &http.Client{
+	rawClient := &http.Client{
 		Timeout: config.ResponseTimeout,
 		Transport: &http.Transport{
 			MaxIdleConns:        config.MaxIdleConns,
@@ -62,20 +124,141 @@ func NewJobSpyAPIClient(config scraper.ScraperConfig, logger *logrus.Logger) *Jo
 		},
 	}
 
+	// The JobSpy search endpoint is a read-only POST, so requests carry an
+	// Idempotent RequestContext (set in callJobSpyAPI) to make it eligible
+	// for the retry middleware despite the method.
+	httpClient := middleware.NewChain(rawClient,
+		middleware.NewRetryMiddleware(3, 500*time.Millisecond, 10*time.Second),
+		middleware.NewDedupMiddleware(5*time.Second),
+		middleware.NewReferrerMiddleware(),
+		middleware.NewValidationMiddleware("application/json"),
+	)
+
 	// Default to local JobSpy API
 	apiBaseURL := config.BaseURL
 	if apiBaseURL == "" {
 		apiBaseURL = "http://localhost:8000"
 	}
 
-	return &JobSpyAPIClient{
+	c := &JobSpyAPIClient{
 		config:       config,
 		logger:       logger,
 		httpClient:   httpClient,
 		metrics:      &scraper.ScrapingMetrics{},
 		healthStatus: protocol.NewHealthStatus(config.WorkerID, protocol.ScraperType("jobspy")),
 		apiBaseURL:   apiBaseURL,
+		siteRegistry: registry,
+		breakers:     resilience.NewBreakerRegistry(breakerFailureThreshold, breakerErrorRateThreshold, breakerWindow, breakerCooldown),
+		fxProvider:   salary.IdentityFXProvider{},
+		limiter:      limiter,
 	}
+
+	if config.ScrapeRulesDir != "" {
+		c.setRulesDir(config.ScrapeRulesDir)
+	}
+
+	if config.RotateProxies {
+		c.setupProxyPool(config)
+	}
+
+	return c
+}
+
+// setupProxyPool builds this client's proxy.Pool and Provider from config
+// and starts the pool's background reconcile loop. Any previously running
+// pool is torn down first, so this is also how ReloadConfig applies a
+// changed ProxyProviderType/ProxySource.
+func (c *JobSpyAPIClient) setupProxyPool(config scraper.ScraperConfig) {
+	c.teardownProxyPool()
+
+	provider, err := proxy.NewProvider(config.ProxyProviderType, config.ProxySource, config.ProxyPool, c.logger)
+	if err != nil {
+		c.logger.WithError(err).Warn("Failed to build proxy provider, proxy pool disabled")
+		return
+	}
+
+	strategy := proxy.RotationStrategy(config.ProxyRotationStrategy)
+	pool := proxy.NewPool(strategy, config.ProxyHealthCheckURL)
+
+	interval := config.ProxyPollInterval
+	if interval <= 0 {
+		interval = defaultProxyPollInterval
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.proxyPool = pool
+	c.proxyProvider = provider
+	c.proxyCancel = cancel
+	go pool.Run(ctx, provider, interval)
+}
+
+// teardownProxyPool stops the current pool's reconcile loop and any
+// provider resources (e.g. a FileProvider's fsnotify watch).
+func (c *JobSpyAPIClient) teardownProxyPool() {
+	if c.proxyCancel != nil {
+		c.proxyCancel()
+		c.proxyCancel = nil
+	}
+	if stoppable, ok := c.proxyProvider.(interface{ Stop() }); ok {
+		stoppable.Stop()
+	}
+	c.proxyProvider = nil
+	c.proxyPool = nil
+}
+
+// setRulesDir (re)loads the rule set from dir and, if that succeeds,
+// (re)starts a RuleWatcher on it so edits take effect without a restart. A
+// missing or empty dir is logged but not fatal - rule-based extraction is
+// best-effort on top of the JobSpy API response, not a prerequisite for it.
+func (c *JobSpyAPIClient) setRulesDir(dir string) {
+	ruleSet, errs := rules.LoadRules(dir)
+	for _, err := range errs {
+		c.logger.WithError(err).Warn("Failed to load a scrape rule file")
+	}
+	c.logger.WithFields(logrus.Fields{"rules_dir": dir, "rules_loaded": len(ruleSet.Rules)}).Info("Loaded scrape rules")
+
+	c.rulesLock.Lock()
+	if c.ruleWatcher != nil {
+		c.ruleWatcher.Stop()
+		c.ruleWatcher = nil
+	}
+	c.ruleSet = ruleSet
+	c.rulesLock.Unlock()
+
+	watcher, err := rules.NewRuleWatcher(dir, c.logger)
+	if err != nil {
+		c.logger.WithError(err).Warn("Failed to start scrape rules watcher, hot-reload disabled")
+		return
+	}
+
+	c.rulesLock.Lock()
+	c.ruleWatcher = watcher
+	c.rulesLock.Unlock()
+
+	go watcher.Run()
+	go func() {
+		for newRuleSet := range watcher.Updates() {
+			c.rulesLock.Lock()
+			c.ruleSet = newRuleSet
+			c.rulesLock.Unlock()
+		}
+	}()
+}
+
+// ruleSetSnapshot returns the currently loaded RuleSet, or nil if none has
+// been loaded (ScrapeRulesDir unset, or loading it failed outright).
+func (c *JobSpyAPIClient) ruleSetSnapshot() *rules.RuleSet {
+	c.rulesLock.RLock()
+	defer c.rulesLock.RUnlock()
+	return c.ruleSet
+}
+
+// RuleVersion implements scraper.RuleVersioner, reporting the fingerprint of
+// whichever rule set is currently loaded (see rules.RuleSet.Version) so a
+// caller like protocol.ComputeTaskHash can fold it into a task's hash
+// without depending on the rules package itself.
+func (c *JobSpyAPIClient) RuleVersion() string {
+	return c.ruleSetSnapshot().Version()
 }
 
 // GetName returns the scraper name
@@ -90,7 +273,10 @@ func (c *JobSpyAPIClient) GetType() protocol.ScraperType {
 
 // Configure sets up the client
 func (c *JobSpyAPIClient) Configure(config scraper.ScraperConfig) error {
+	c.configLock.Lock()
 	c.config = config
+	c.configLock.Unlock()
+
 	c.logger.WithFields(logrus.Fields{
 		"worker_id":    config.WorkerID,
 		"api_base_url": c.apiBaseURL,
@@ -98,6 +284,74 @@ func (c *JobSpyAPIClient) Configure(config scraper.ScraperConfig) error {
 	return nil
 }
 
+// ReloadConfig applies a hot-reloaded configuration. Only the mutable
+// anti-detection fields are expected to change; WorkerID and BaseURL are
+// left untouched even if present in the incoming config so a bad reload
+// can't silently repoint the client at a different API.
+func (c *JobSpyAPIClient) ReloadConfig(config scraper.ScraperConfig) error {
+	c.configLock.Lock()
+	rulesDirChanged := config.ScrapeRulesDir != c.config.ScrapeRulesDir
+	proxySourceChanged := config.ProxyProviderType != c.config.ProxyProviderType ||
+		config.ProxySource != c.config.ProxySource ||
+		config.RotateProxies != c.config.RotateProxies
+	staticPoolChanged := !proxySourceChanged && !equalStrings(config.ProxyPool, c.config.ProxyPool)
+
+	c.config.ProxyPool = config.ProxyPool
+	c.config.UserAgents = config.UserAgents
+	c.config.MinDelay = config.MinDelay
+	c.config.MaxDelay = config.MaxDelay
+	c.config.RotateProxies = config.RotateProxies
+	c.config.RotateUserAgents = config.RotateUserAgents
+	c.config.RateLimitRPM = config.RateLimitRPM
+	c.config.ScrapeRulesDir = config.ScrapeRulesDir
+	c.config.ProxyProviderType = config.ProxyProviderType
+	c.config.ProxySource = config.ProxySource
+	c.config.ProxyRotationStrategy = config.ProxyRotationStrategy
+	c.config.ProxyHealthCheckURL = config.ProxyHealthCheckURL
+	c.config.ProxyPollInterval = config.ProxyPollInterval
+	c.configLock.Unlock()
+
+	if rulesDirChanged && config.ScrapeRulesDir != "" {
+		c.setRulesDir(config.ScrapeRulesDir)
+	}
+
+	switch {
+	case proxySourceChanged && config.RotateProxies:
+		c.setupProxyPool(config)
+	case proxySourceChanged && !config.RotateProxies:
+		c.teardownProxyPool()
+	case staticPoolChanged && c.proxyPool != nil && (config.ProxyProviderType == "" || strings.EqualFold(config.ProxyProviderType, "static")):
+		if err := c.proxyPool.ReloadPool(config.ProxyPool); err != nil {
+			c.logger.WithError(err).Warn("Failed to reload static proxy pool")
+		}
+	}
+
+	c.logger.Info("JobSpy API client configuration hot-reloaded")
+	return nil
+}
+
+// equalStrings reports whether a and b contain the same strings in the
+// same order.
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// configSnapshot returns a copy of the current config, safe to read without
+// holding configLock afterwards.
+func (c *JobSpyAPIClient) configSnapshot() scraper.ScraperConfig {
+	c.configLock.RLock()
+	defer c.configLock.RUnlock()
+	return c.config
+}
+
 // ValidateParams validates scraping parameters
 func (c *JobSpyAPIClient) ValidateParams(params protocol.ScrapingTaskParams) error {
 	if params.SearchTerm == "" {
@@ -152,21 +406,28 @@ func (c *JobSpyAPIClient) ScrapeJobs(ctx context.Context, params protocol.Scrapi
 		return result, err
 	}
 
-	// Convert task params to JobSpy API request
-	jobspyRequest := c.convertToJobSpyRequest(params)
+	// Collect the streaming core's output into a plain slice. This is the
+	// "thin wrapper" side of ScrapeJobsStream: everything else (request
+	// building, decoding, salary normalization and filtering) lives in
+	// scrapeJobsStream so the two entry points can't drift apart.
+	var protocolJobs []protocol.JobData
+	var decodeIssues []protocol.DecodeIssue
+	appliedRules := make(map[string]bool)
+	collect := func(job protocol.JobData, issues []protocol.DecodeIssue, appliedRule string) {
+		protocolJobs = append(protocolJobs, job)
+		decodeIssues = append(decodeIssues, issues...)
+		if appliedRule != "" {
+			appliedRules[appliedRule] = true
+		}
+	}
 
-	// Make API call
-	jobs, err := c.callJobSpyAPI(ctx, jobspyRequest)
-	if err != nil {
+	if err := c.scrapeJobsStream(ctx, params, collect); err != nil {
 		result.Status = protocol.TaskStatusFailed
 		errorMsg := fmt.Sprintf("JobSpy API call failed: %v", err)
 		result.Error = &errorMsg
 		return result, err
 	}
 
-	// Convert response to protocol format
-	protocolJobs := c.convertToProtocolJobs(jobs)
-
 	// Finalize metrics and result
 	c.metrics.EndTime = time.Now()
 	c.metrics.JobsFound = len(protocolJobs)
@@ -189,7 +450,9 @@ func (c *JobSpyAPIClient) ScrapeJobs(ctx context.Context, params protocol.Scrapi
 		CaptchaEncountered:  false,
 		BlockedRequests:     0,
 		AverageResponseTime: c.metrics.AverageResponseTime.Seconds(),
+		DecodeWarnings:      decodeIssues,
 		WorkerID:            &c.config.WorkerID,
+		AppliedRules:        ruleNames(appliedRules),
 	}
 
 	c.logger.WithFields(logrus.Fields{
@@ -201,6 +464,66 @@ func (c *JobSpyAPIClient) ScrapeJobs(ctx context.Context, params protocol.Scrapi
 	return result, nil
 }
 
+// ScrapeJobsStream performs the same scrape as ScrapeJobs, but emits each
+// decoded, salary-filtered job onto out as soon as it's available instead
+// of buffering the whole result set - see callJobSpyAPIStream. out is
+// always closed when the scrape ends, whether it succeeds or fails, so a
+// caller can simply range over it. Per-job decode issues are logged rather
+// than collected here, since this path has nowhere to aggregate them into;
+// use ScrapeJobs if you need them in ScrapingResult.Metadata.
+func (c *JobSpyAPIClient) ScrapeJobsStream(ctx context.Context, params protocol.ScrapingTaskParams, out chan<- protocol.JobData) error {
+	defer close(out)
+
+	return c.scrapeJobsStream(ctx, params, func(job protocol.JobData, issues []protocol.DecodeIssue, appliedRule string) {
+		for _, issue := range issues {
+			c.logger.WithFields(logrus.Fields{
+				"field": issue.Field,
+				"value": issue.Value,
+				"index": issue.Index,
+			}).Warn("JobSpy job field failed to decode: " + issue.Message)
+		}
+		if appliedRule != "" {
+			c.logger.WithField("rule", appliedRule).Debug("Applied scrape rule to job")
+		}
+
+		select {
+		case out <- job:
+		case <-ctx.Done():
+		}
+	})
+}
+
+// scrapeJobsStream is the shared core behind ScrapeJobs and
+// ScrapeJobsStream: it validates params, calls the JobSpy API through the
+// streaming json.Decoder path, and invokes onJob for every job that passes
+// the salary floor, alongside any decode issues found while typing it.
+func (c *JobSpyAPIClient) scrapeJobsStream(ctx context.Context, params protocol.ScrapingTaskParams, onJob func(protocol.JobData, []protocol.DecodeIssue, string)) error {
+	if err := c.ValidateParams(params); err != nil {
+		return err
+	}
+
+	jobspyRequest := c.convertToJobSpyRequest(params)
+	ruleSet := c.ruleSetSnapshot()
+
+	index := 0
+	return c.callJobSpyAPIStream(ctx, jobspyRequest, params.TaskHash, func(raw map[string]interface{}) {
+		typedJob, issues := DecodeJobSpyJob(raw)
+		for i := range issues {
+			issues[i].Index = index
+		}
+		index++
+
+		protocolJob := c.convertOneJobSpyJob(typedJob)
+		if !passesSalaryMin(protocolJob, params.SalaryMin) {
+			return
+		}
+
+		var appliedRule string
+		protocolJob, appliedRule = applyRule(ruleSet, typedJob.Site, protocolJob, c.logger)
+		onJob(protocolJob, issues, appliedRule)
+	})
+}
+
 // convertToJobSpyRequest converts protocol params to JobSpy API request format
 func (c *JobSpyAPIClient) convertToJobSpyRequest(params protocol.ScrapingTaskParams) JobSpyRequest {
 	request := JobSpyRequest{
@@ -224,16 +547,57 @@ func (c *JobSpyAPIClient) convertToJobSpyRequest(params protocol.ScrapingTaskPar
 		request.IsRemote = params.IsRemote
 	}
 
-	if params.SalaryMin != nil && *params.SalaryMin > 0 {
-		// JobSpy doesn't have direct salary filtering in API
-		// This could be handled in post-processing
-	}
+	// JobSpy's API has no request field for a salary floor - params.SalaryMin
+	// is instead enforced as a post-processing filter in ScrapeJobs, after
+	// results come back and have been normalized to annual USD.
 
 	return request
 }
 
-// callJobSpyAPI makes the actual HTTP call to JobSpy API
-func (c *JobSpyAPIClient) callJobSpyAPI(ctx context.Context, request JobSpyRequest) ([]map[string]interface{}, error) {
+// doJobSpyRequest staggers and sends the search request, handling the
+// circuit breaker and response status, and returns the still-open response
+// body for the caller to consume - either buffered whole (callJobSpyAPI) or
+// decoded incrementally (callJobSpyAPIStream). The caller owns closing the
+// response body on a non-error return.
+func (c *JobSpyAPIClient) doJobSpyRequest(ctx context.Context, request JobSpyRequest, taskHash string) (*http.Response, error) {
+	// Stagger concurrent calls against the same API base URL and add
+	// bounded-uniform jitter around the configured delay range so workers
+	// don't all hammer the target sites in lockstep.
+	if c.siteRegistry != nil {
+		if err := c.siteRegistry.Wait(ctx, c.apiBaseURL); err != nil {
+			return nil, fmt.Errorf("stagger wait interrupted: %w", err)
+		}
+	}
+	cfg := c.configSnapshot()
+	if err := scraper.SleepJittered(ctx, cfg); err != nil {
+		return nil, fmt.Errorf("jitter delay interrupted: %w", err)
+	}
+
+	breaker := c.breakers.Get(c.apiBaseURL)
+	if !breaker.Allow() {
+		return nil, scraper.ScrapingError{
+			Type:      scraper.ErrorTypeCircuitOpen,
+			Message:   "JobSpy API circuit breaker is open, short-circuiting call",
+			URL:       c.apiBaseURL,
+			Retryable: true,
+		}
+	}
+
+	limiterTuple := ratelimit.Tuple{Site: c.apiBaseURL, Region: cfg.Region}
+	if c.limiter != nil {
+		if !c.limiter.Allow(limiterTuple) {
+			return nil, scraper.ScrapingError{
+				Type:      scraper.ErrorTypeCircuitOpen,
+				Message:   "JobSpy API adaptive rate limiter breaker is open, short-circuiting call",
+				URL:       c.apiBaseURL,
+				Retryable: true,
+			}
+		}
+		if err := c.limiter.Wait(ctx, limiterTuple, cfg.RateLimitRPM); err != nil {
+			return nil, fmt.Errorf("rate limiter wait interrupted: %w", err)
+		}
+	}
+
 	startTime := time.Now()
 
 	// Marshal request to JSON
@@ -242,9 +606,13 @@ func (c *JobSpyAPIClient) callJobSpyAPI(ctx context.Context, request JobSpyReque
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
+	// The search endpoint is read-only despite being a POST, so mark it
+	// Idempotent for the retry middleware.
+	reqCtx := middleware.WithRequestContext(ctx, &middleware.RequestContext{Idempotent: true})
+
 	// Create HTTP request
 	apiURL := fmt.Sprintf("%s/api/v1/search_jobs", c.apiBaseURL)
-	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, bytes.NewBuffer(requestBody))
+	req, err := http.NewRequestWithContext(reqCtx, "POST", apiURL, bytes.NewBuffer(requestBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -252,20 +620,28 @@ func (c *JobSpyAPIClient) callJobSpyAPI(ctx context.Context, request JobSpyReque
 	// Set headers
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
-	
+
+	// X-Task-Hash lets an operator correlate this call - and any delayed
+	// anti-bot challenge or async response it triggers - back to the
+	// protocol.ScrapingTask that caused it (see protocol.ComputeTaskHash
+	// and the "worker search --hash" CLI command) by hash alone.
+	if taskHash != "" {
+		req.Header.Set("X-Task-Hash", taskHash)
+	}
+
 	// Add API key if configured
-	if len(c.config.UserAgents) > 0 {
+	if len(cfg.UserAgents) > 0 {
 		// Use UserAgents config field to pass API key for now
-		req.Header.Set("x-api-key", c.config.UserAgents[0])
+		req.Header.Set("x-api-key", cfg.UserAgents[0])
 	}
 
 	// Make request
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		c.metrics.BlockedRequests++
+		breaker.RecordFailure()
 		return nil, fmt.Errorf("HTTP request failed: %w", err)
 	}
-	defer resp.Body.Close()
 
 	// Update metrics
 	c.metrics.RequestsMade++
@@ -274,7 +650,10 @@ func (c *JobSpyAPIClient) callJobSpyAPI(ctx context.Context, request JobSpyReque
 	// Check response status
 	if resp.StatusCode != 200 {
 		if resp.StatusCode == 429 {
+			defer resp.Body.Close()
 			c.metrics.RateLimitHits++
+			breaker.RecordFailure()
+			c.reportLimiterOutcome(limiterTuple, resp, cfg.RespectRetryAfter)
 			return nil, scraper.ScrapingError{
 				Type:       scraper.ErrorTypeRateLimit,
 				Message:    "Rate limited by JobSpy API",
@@ -285,7 +664,14 @@ func (c *JobSpyAPIClient) callJobSpyAPI(ctx context.Context, request JobSpyReque
 		}
 
 		if resp.StatusCode >= 400 {
+			defer resp.Body.Close()
 			c.metrics.BlockedRequests++
+			if resp.StatusCode >= 500 {
+				breaker.RecordFailure()
+			} else {
+				breaker.RecordSuccess()
+			}
+			c.reportLimiterOutcome(limiterTuple, resp, cfg.RespectRetryAfter)
 			return nil, scraper.ScrapingError{
 				Type:       scraper.ErrorTypeBlocked,
 				Message:    fmt.Sprintf("Request failed with status %d", resp.StatusCode),
@@ -296,6 +682,41 @@ func (c *JobSpyAPIClient) callJobSpyAPI(ctx context.Context, request JobSpyReque
 		}
 	}
 
+	breaker.RecordSuccess()
+	c.reportLimiterOutcome(limiterTuple, resp, cfg.RespectRetryAfter)
+	return resp, nil
+}
+
+// reportLimiterOutcome feeds resp's status code (and Retry-After header, if
+// respectRetryAfter is set) into c.limiter for tuple. This client doesn't
+// wire middleware.CaptchaDetectorMiddleware into its HTTP chain, so
+// captchaDetected is always false here - a future backend that does use it
+// can report true instead. A nil limiter makes this a no-op.
+func (c *JobSpyAPIClient) reportLimiterOutcome(tuple ratelimit.Tuple, resp *http.Response, respectRetryAfter bool) {
+	if c.limiter == nil {
+		return
+	}
+
+	var retryAfter time.Duration
+	if respectRetryAfter {
+		retryAfter, _ = ratelimit.ParseRetryAfter(resp.Header.Get("Retry-After"))
+	}
+
+	c.limiter.ReportOutcome(tuple, resp.StatusCode, false, respectRetryAfter, retryAfter)
+}
+
+// callJobSpyAPI makes the actual HTTP call to JobSpy API, buffering and
+// unmarshaling the whole response body. See callJobSpyAPIStream for the
+// incremental-decoding alternative used by ScrapeJobsStream.
+func (c *JobSpyAPIClient) callJobSpyAPI(ctx context.Context, request JobSpyRequest, taskHash string) ([]map[string]interface{}, error) {
+	startTime := time.Now()
+
+	resp, err := c.doJobSpyRequest(ctx, request, taskHash)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
 	// Read response body
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -309,90 +730,194 @@ func (c *JobSpyAPIClient) callJobSpyAPI(ctx context.Context, request JobSpyReque
 	}
 
 	c.logger.WithFields(logrus.Fields{
-		"api_url":      apiURL,
-		"jobs_count":   jobspyResponse.Count,
-		"cached":       jobspyResponse.Cached,
-		"status_code":  resp.StatusCode,
-		"duration":     time.Since(startTime),
+		"jobs_count":  jobspyResponse.Count,
+		"cached":      jobspyResponse.Cached,
+		"status_code": resp.StatusCode,
+		"duration":    time.Since(startTime),
 	}).Debug("JobSpy API call completed")
 
 	return jobspyResponse.Jobs, nil
 }
 
-// convertToProtocolJobs converts JobSpy API response to protocol format
-func (c *JobSpyAPIClient) convertToProtocolJobs(jobs []map[string]interface{}) []protocol.JobData {
-	var protocolJobs []protocol.JobData
+// callJobSpyAPIStream makes the same API call as callJobSpyAPI, but decodes
+// the response body with a json.Decoder instead of buffering it whole,
+// invoking onJob for each entry in the "jobs" array as soon as it's parsed.
+// The decoder itself holds roughly one job's worth of JSON at a time, but
+// the DedupMiddleware in this client's HTTP chain reads the whole response
+// body into memory before it reaches here, so peak memory is still one full
+// response.
+func (c *JobSpyAPIClient) callJobSpyAPIStream(ctx context.Context, request JobSpyRequest, taskHash string, onJob func(map[string]interface{})) error {
+	resp, err := c.doJobSpyRequest(ctx, request, taskHash)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
 
-	for _, job := range jobs {
-		protocolJob := protocol.JobData{
-			SalaryCurrency: "USD",
-			IsRemote:       false,
-			EasyApply:      false,
-			Skills:         []string{},
-			Benefits:       []string{},
-		}
+	dec := json.NewDecoder(resp.Body)
 
-		// Extract fields with type checking
-		if title, ok := job["TITLE"].(string); ok {
-			protocolJob.Title = title
+	// The response is a single top-level object; walk its keys and only
+	// decode "jobs" element-by-element, so any fields JobSpy adds before or
+	// after it don't need special-casing here.
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+	for dec.More() {
+		keyToken, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("failed to read response body: %w", err)
 		}
+		key, _ := keyToken.(string)
 
-		if company, ok := job["COMPANY"].(string); ok {
-			protocolJob.Company = company
+		if key != "jobs" {
+			var discard interface{}
+			if err := dec.Decode(&discard); err != nil {
+				return fmt.Errorf("failed to read response body: %w", err)
+			}
+			continue
 		}
 
-		if location, ok := job["LOCATION"].(string); ok {
-			protocolJob.Location = location
+		if _, err := dec.Token(); err != nil {
+			return fmt.Errorf("failed to read jobs array: %w", err)
 		}
-
-		if jobURL, ok := job["JOB_URL"].(string); ok {
-			protocolJob.JobURL = jobURL
+		for dec.More() {
+			var job map[string]interface{}
+			if err := dec.Decode(&job); err != nil {
+				return fmt.Errorf("failed to decode job entry: %w", err)
+			}
+			onJob(job)
 		}
-
-		if description, ok := job["DESCRIPTION"].(string); ok {
-			protocolJob.Description = description
+		if _, err := dec.Token(); err != nil {
+			return fmt.Errorf("failed to read jobs array: %w", err)
 		}
+	}
 
-		if datePosted, ok := job["DATE_POSTED"].(string); ok {
-			protocolJob.PostedDate = &datePosted
-		}
+	return nil
+}
 
-		if minAmount, ok := job["MIN_AMOUNT"].(float64); ok {
-			protocolJob.SalaryMin = &minAmount
-		}
+// convertOneJobSpyJob converts a single typed JobSpy job into protocol
+// format, normalizing its salary to an annual targetSalaryCurrency figure.
+func (c *JobSpyAPIClient) convertOneJobSpyJob(job JobSpyJob) protocol.JobData {
+	protocolJob := protocol.JobData{
+		Title:       job.Title,
+		Company:     job.Company,
+		Location:    job.Location,
+		JobURL:      job.JobURL,
+		Description: job.Description,
+		IsRemote:    job.IsRemote,
+		EasyApply:   job.EasyApply,
+		Skills:      []string{},
+		Benefits:    []string{},
+	}
 
-		if maxAmount, ok := job["MAX_AMOUNT"].(float64); ok {
-			protocolJob.SalaryMax = &maxAmount
-		}
+	if job.DatePosted != "" {
+		datePosted := job.DatePosted
+		protocolJob.PostedDate = &datePosted
+	}
+	if job.JobType != "" {
+		jobType := job.JobType
+		protocolJob.JobType = &jobType
+	}
+	if job.JobURLDirect != "" {
+		applyURL := job.JobURLDirect
+		protocolJob.ApplyURL = &applyURL
+	}
+	if job.CompanyLogo != "" {
+		companyLogo := job.CompanyLogo
+		protocolJob.CompanyLogo = &companyLogo
+	}
 
-		if currency, ok := job["CURRENCY"].(string); ok {
-			protocolJob.SalaryCurrency = currency
-		}
+	currency := job.Currency
+	if currency == "" {
+		currency = targetSalaryCurrency
+	}
+	annualMin, annualMax := salary.EnforceAnnualSalary(job.MinAmount, job.MaxAmount, salary.Period(job.Interval))
+	convertedMin, convertedMax, resolvedCurrency, err := salary.NormalizeCurrency(annualMin, annualMax, currency, targetSalaryCurrency, c.fxProvider)
+	if err != nil {
+		c.logger.WithError(err).WithField("job_title", job.Title).Warn("Failed to convert salary to target currency, leaving it in the original currency")
+		protocolJob.SalaryMin, protocolJob.SalaryMax, protocolJob.SalaryCurrency = annualMin, annualMax, currency
+	} else {
+		protocolJob.SalaryMin, protocolJob.SalaryMax, protocolJob.SalaryCurrency = convertedMin, convertedMax, resolvedCurrency
+	}
 
-		if jobType, ok := job["JOB_TYPE"].(string); ok {
-			protocolJob.JobType = &jobType
-		}
+	return protocolJob
+}
 
-		if isRemote, ok := job["IS_REMOTE"].(bool); ok {
-			protocolJob.IsRemote = isRemote
-		}
+// applyRule matches site against ruleSet and, if a rule exists for it, runs
+// it against job.Description and merges the handful of fields this client
+// knows how to fold back into protocol.JobData: "skills" and "benefits"
+// (comma-separated lists, appended) and "requirements" (set if unset).
+// Anything else Extract returns is dropped - JobData has no free-form extra
+// field to put it in - but the rule itself is still reported as applied, so
+// an operator can see it matched even if every field it adds is unsupported
+// here. Returns the rule's "site/page_type@version" key, or "" if no rule
+// matched.
+func applyRule(ruleSet *rules.RuleSet, site string, job protocol.JobData, logger *logrus.Logger) (protocol.JobData, string) {
+	if ruleSet == nil || site == "" {
+		return job, ""
+	}
+	rule, ok := ruleSet.Match(site, rulePageType)
+	if !ok {
+		return job, ""
+	}
 
-		if applyURL, ok := job["JOB_URL_DIRECT"].(string); ok {
-			protocolJob.ApplyURL = &applyURL
-		}
+	fields, errs := rule.Extract(job.Description)
+	for _, err := range errs {
+		logger.WithError(err).Warn("Scrape rule selector could not be applied")
+	}
 
-		if easyApply, ok := job["EASY_APPLY"].(bool); ok {
-			protocolJob.EasyApply = easyApply
+	for name, value := range fields {
+		switch strings.ToLower(name) {
+		case "skills":
+			job.Skills = append(job.Skills, splitList(value)...)
+		case "benefits":
+			job.Benefits = append(job.Benefits, splitList(value)...)
+		case "requirements":
+			if job.Requirements == nil {
+				v := value
+				job.Requirements = &v
+			}
 		}
+	}
+
+	return job, fmt.Sprintf("%s/%s@%s", rule.Site, rule.PageType, rule.Version)
+}
 
-		if companyLogo, ok := job["COMPANY_LOGO"].(string); ok {
-			protocolJob.CompanyLogo = &companyLogo
+// splitList splits a comma-separated extracted field into trimmed, non-empty
+// items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
 		}
+	}
+	return items
+}
 
-		protocolJobs = append(protocolJobs, protocolJob)
+// ruleNames returns the keys of a set of applied rule names as a sorted
+// slice, so ScrapingMetadata.AppliedRules is deterministic across runs.
+func ruleNames(applied map[string]bool) []string {
+	if len(applied) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(applied))
+	for name := range applied {
+		names = append(names, name)
 	}
+	sort.Strings(names)
+	return names
+}
 
-	return protocolJobs
+// passesSalaryMin reports whether job should be kept given salaryMin: a job
+// with no salary data at all passes, since JobSpy often just doesn't have
+// that field for a given posting, but a job with a known salary ceiling
+// below the floor is dropped.
+func passesSalaryMin(job protocol.JobData, salaryMin *int) bool {
+	if salaryMin == nil {
+		return true
+	}
+	return job.SalaryMax == nil || *job.SalaryMax >= float64(*salaryMin)
 }
 
 // GetHealthStatus returns current health status
@@ -409,11 +934,46 @@ func (c *JobSpyAPIClient) GetHealthStatus() *protocol.HealthStatus {
 		c.healthStatus.Status = "healthy"
 	}
 
+	if c.proxyPool != nil {
+		stats := c.proxyPool.GetProxyStats()
+		c.healthStatus.ProxyPoolSize = len(c.proxyPool.GetHealthyProxies())
+		c.healthStatus.ProxySuccessRate = averageSuccessRate(stats)
+	}
+
 	return c.healthStatus
 }
 
+// averageSuccessRate is the mean ProxyStats.SuccessRate across every
+// tracked proxy, ignoring ones that have never been used (SuccessRate 0
+// with zero total requests would otherwise drag the average down before a
+// newly added proxy has had a chance to prove itself).
+func averageSuccessRate(stats map[string]scraper.ProxyStats) float64 {
+	var total float64
+	var count int
+	for _, s := range stats {
+		if s.SuccessCount+s.FailureCount == 0 {
+			continue
+		}
+		total += s.SuccessRate
+		count++
+	}
+	if count == 0 {
+		return 0
+	}
+	return total / float64(count)
+}
+
 // Close cleans up resources
 func (c *JobSpyAPIClient) Close() error {
+	c.rulesLock.Lock()
+	if c.ruleWatcher != nil {
+		c.ruleWatcher.Stop()
+		c.ruleWatcher = nil
+	}
+	c.rulesLock.Unlock()
+
+	c.teardownProxyPool()
+
 	c.logger.Info("JobSpy API client shutting down")
 	return nil
 }
\ No newline at end of file
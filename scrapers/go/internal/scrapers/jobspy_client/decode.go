@@ -0,0 +1,127 @@
+package jobspy_client
+
+import (
+	"fmt"
+
+	"github.com/jobspy/scrapers/internal/protocol"
+)
+
+// JobSpyJob is the typed shape of one entry in JobSpyResponse.Jobs. JobSpy
+// returns each job as a bare JSON object keyed by the job board's own
+// upper-cased column names.
+type JobSpyJob struct {
+	Title        string
+	Company      string
+	Location     string
+	JobURL       string
+	JobURLDirect string
+	Description  string
+	DatePosted   string
+	MinAmount    *float64
+	MaxAmount    *float64
+	Interval     string
+	Currency     string
+	JobType      string
+	IsRemote     bool
+	EasyApply    bool
+	CompanyLogo  string
+	// Site is the job board this entry came from (e.g. "indeed",
+	// "linkedin", "glassdoor"), used to pick a matching scraper/rules.Rule.
+	Site string
+}
+
+// DecodeJobSpyJob picks apart one raw job entry into a JobSpyJob. Unlike a
+// bare type assertion, a present field whose JSON type doesn't match what's
+// expected is reported as a protocol.DecodeIssue (with the field name and
+// the offending raw value) instead of silently becoming a zero value. A
+// field that's simply absent is not an issue - JobSpy doesn't guarantee
+// every column is populated for every site.
+func DecodeJobSpyJob(raw map[string]interface{}) (JobSpyJob, []protocol.DecodeIssue) {
+	var job JobSpyJob
+	var issues []protocol.DecodeIssue
+
+	fail := func(field string, value interface{}, message string) {
+		issues = append(issues, protocol.DecodeIssue{
+			Field:   field,
+			Value:   fmt.Sprintf("%v", value),
+			Message: message,
+		})
+	}
+
+	str := func(field string, dest *string) {
+		v, present := raw[field]
+		if !present {
+			return
+		}
+		s, ok := v.(string)
+		if !ok {
+			fail(field, v, "expected a string")
+			return
+		}
+		*dest = s
+	}
+
+	floatPtr := func(field string, dest **float64) {
+		v, present := raw[field]
+		if !present {
+			return
+		}
+		f, ok := v.(float64)
+		if !ok {
+			fail(field, v, "expected a number")
+			return
+		}
+		*dest = &f
+	}
+
+	boolean := func(field string, dest *bool) {
+		v, present := raw[field]
+		if !present {
+			return
+		}
+		b, ok := v.(bool)
+		if !ok {
+			fail(field, v, "expected a boolean")
+			return
+		}
+		*dest = b
+	}
+
+	str("TITLE", &job.Title)
+	str("COMPANY", &job.Company)
+	str("LOCATION", &job.Location)
+	str("JOB_URL", &job.JobURL)
+	str("JOB_URL_DIRECT", &job.JobURLDirect)
+	str("DESCRIPTION", &job.Description)
+	str("DATE_POSTED", &job.DatePosted)
+	floatPtr("MIN_AMOUNT", &job.MinAmount)
+	floatPtr("MAX_AMOUNT", &job.MaxAmount)
+	str("INTERVAL", &job.Interval)
+	str("CURRENCY", &job.Currency)
+	str("JOB_TYPE", &job.JobType)
+	boolean("IS_REMOTE", &job.IsRemote)
+	boolean("EASY_APPLY", &job.EasyApply)
+	str("COMPANY_LOGO", &job.CompanyLogo)
+	str("SITE", &job.Site)
+
+	return job, issues
+}
+
+// DecodeJobSpyJobs decodes every entry in raw, tagging each issue with the
+// entry's index so a caller can tell which job in the batch it came from.
+// A malformed field only drops that field, not the whole entry.
+func DecodeJobSpyJobs(raw []map[string]interface{}) ([]JobSpyJob, []protocol.DecodeIssue) {
+	jobs := make([]JobSpyJob, 0, len(raw))
+	var issues []protocol.DecodeIssue
+
+	for i, entry := range raw {
+		job, jobIssues := DecodeJobSpyJob(entry)
+		for _, issue := range jobIssues {
+			issue.Index = i
+			issues = append(issues, issue)
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, issues
+}
@@ -0,0 +1,52 @@
+package jobspy_client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/jobspy/scrapers/internal/ratelimit"
+	"github.com/jobspy/scrapers/internal/scraper"
+)
+
+// backendName is how this package registers itself with scraper.Register.
+const backendName = "jobspy"
+
+func init() {
+	scraper.Register(backendName, newJobSpyScraper)
+}
+
+// newJobSpyScraper is the scraper.Constructor for the "jobspy" backend. It
+// applies the JobSpy API client's defaults before constructing and
+// configuring the client, matching scraper.Factory's previous hardcoded
+// behavior now that any number of backends can register themselves here.
+func newJobSpyScraper(config scraper.ScraperConfig, logger *logrus.Logger, registry *scraper.SiteRegistry, limiter *ratelimit.AdaptiveLimiter) (scraper.Scraper, error) {
+	clientConfig := config
+	if clientConfig.BaseURL == "" {
+		// Default to local JobSpy API instance
+		clientConfig.BaseURL = "http://localhost:8000"
+	}
+	if clientConfig.ResponseTimeout == 0 {
+		clientConfig.ResponseTimeout = 60 * time.Second // 60 second timeout for JobSpy API calls
+	}
+	if clientConfig.MaxIdleConns == 0 {
+		clientConfig.MaxIdleConns = 10
+	}
+	if clientConfig.MaxConnsPerHost == 0 {
+		clientConfig.MaxConnsPerHost = 10
+	}
+
+	// Set API key if provided (stored in UserAgents field for simplicity)
+	if len(clientConfig.UserAgents) == 0 {
+		clientConfig.UserAgents = []string{""} // Empty API key by default
+	}
+
+	client := NewJobSpyAPIClient(clientConfig, logger, registry, limiter)
+	if err := client.Configure(clientConfig); err != nil {
+		return nil, fmt.Errorf("failed to configure JobSpy API client: %w", err)
+	}
+
+	logger.WithField("api_url", clientConfig.BaseURL).Info("JobSpy API client created")
+	return client, nil
+}
@@ -0,0 +1,83 @@
+package worker
+
+import (
+	"os"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/load"
+	"github.com/shirou/gopsutil/v3/process"
+	"github.com/sirupsen/logrus"
+)
+
+// cpuSampler wraps the gopsutil state that must persist across ticks:
+// cpu.Percent and process.Percent each compute a delta against their own
+// previous call, so a fresh sampler used once per tick would always report
+// the spike from process/host start to that first call instead of a
+// steady-state rate. Held once on HealthMonitor and reused every
+// systemMetricsLoop tick for exactly that reason.
+type cpuSampler struct {
+	proc *process.Process
+}
+
+// newCPUSampler builds a sampler for the current process and primes both
+// stateful percent calculations so the first real sample (the next
+// collectSystemMetrics tick) is a delta since newCPUSampler ran, not since
+// process/host start.
+func newCPUSampler() (*cpuSampler, error) {
+	proc, err := process.NewProcess(int32(os.Getpid()))
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := cpu.Percent(0, false); err != nil {
+		return nil, err
+	}
+	if _, err := proc.Percent(0); err != nil {
+		return nil, err
+	}
+
+	return &cpuSampler{proc: proc}, nil
+}
+
+// cpuSample is one systemMetricsLoop tick's reading.
+type cpuSample struct {
+	HostCPUPercent    float64
+	ProcessCPUPercent float64
+	LoadAverage1m     float64
+	OpenFDs           int
+}
+
+// sample reads host CPU, this process's own CPU, 1-minute load average,
+// and open file descriptor count. A failed individual reading is logged
+// and left at its zero value rather than aborting the whole sample -
+// OpenFDs isn't implemented on every platform gopsutil supports, for
+// instance, and that alone shouldn't blank out CPU numbers that did work.
+func (s *cpuSampler) sample(logger *logrus.Logger) cpuSample {
+	var result cpuSample
+
+	if percents, err := cpu.Percent(0, false); err != nil {
+		logger.WithError(err).Warn("Failed to sample host CPU usage")
+	} else if len(percents) > 0 {
+		result.HostCPUPercent = percents[0]
+	}
+
+	if pct, err := s.proc.Percent(0); err != nil {
+		logger.WithError(err).Warn("Failed to sample process CPU usage")
+	} else {
+		result.ProcessCPUPercent = pct
+	}
+
+	if avg, err := load.Avg(); err != nil {
+		logger.WithError(err).Warn("Failed to sample load average")
+	} else {
+		result.LoadAverage1m = avg.Load1
+	}
+
+	if fds, err := s.proc.NumFDs(); err != nil {
+		logger.WithError(err).Warn("Failed to sample open file descriptors")
+	} else {
+		result.OpenFDs = int(fds)
+	}
+
+	return result
+}
@@ -33,8 +33,46 @@ type HealthMonitor struct {
 	taskMetrics      *TaskMetrics
 	systemMetrics    *SystemMetrics
 	metricsLock      sync.RWMutex
+
+	// metrics is the embedded Prometheus /metrics server (see metrics.go);
+	// nil when config.MetricsEnabled is false or config.MetricsAddr is
+	// empty, in which case health data is only ever pushed to Redis.
+	metrics *metricsServer
+
+	// cpuSampler holds gopsutil's stateful CPU counters (see cpu_sampler.go);
+	// nil if it failed to initialize, in which case CPU/load/FD fields on
+	// SystemMetrics simply stay at their zero value.
+	cpuSampler *cpuSampler
+
+	// healthCheckFunc, if set via SetHealthCheckFunc, is invoked after
+	// every broker connectivity probe (see checkBrokerHealth) with that
+	// probe's result - nil on success, the Ping error otherwise.
+	healthCheckFunc HealthCheckFunc
+
+	// brokerConsecutiveFailures counts consecutive failed broker probes;
+	// guarded by healthLock alongside the HealthStatus fields it feeds.
+	brokerConsecutiveFailures int
+
+	// peers holds the most recent gossiped HealthStatus from other workers
+	// of the same ScraperType (see peer_health.go), keyed by WorkerID and
+	// guarded by peersLock.
+	peers     map[string]*peerRecord
+	peersLock sync.Mutex
 }
 
+// HealthCheckFunc is invoked after every HealthMonitor broker connectivity
+// probe with that probe's result (nil on success) - the same pattern
+// asynq's healthchecker uses to decouple "is the broker reachable" from
+// whatever alerting or circuit-breaker logic a caller wants to run in
+// response, without HealthMonitor itself knowing about either.
+type HealthCheckFunc func(err error)
+
+// brokerUnhealthyAfter is how many consecutive failed broker probes
+// (see checkBrokerHealth) force HealthStatus.Status to "unhealthy",
+// overriding whatever calculateHealthStatus would otherwise report from
+// task/system metrics alone.
+const brokerUnhealthyAfter = 3
+
 // TaskMetrics holds task-related metrics
 type TaskMetrics struct {
 	TasksCompletedLastHour   int
@@ -42,26 +80,45 @@ type TaskMetrics struct {
 	LastSuccessfulScrape     time.Time
 	ErrorRateLastHour        float64
 	AverageResponseTime      time.Duration
-	
-	// Rolling windows
-	successWindow    []time.Time
-	errorWindow      []time.Time
-	responseTimeWindow []time.Duration
+	P50ResponseTime          time.Duration
+	P95ResponseTime          time.Duration
+	P99ResponseTime          time.Duration
+
+	// ErrorsByCategory is the last hour's failed task count per
+	// protocol.ErrorCategory, and SuggestedBackoff is the backoff hint
+	// calculateHealthStatus derives from a CategoryRateLimit spike (see
+	// updateTaskMetrics).
+	ErrorsByCategory map[protocol.ErrorCategory]int
+	SuggestedBackoff time.Duration
+
+	// window replaces the old unbounded successWindow/errorWindow/
+	// responseTimeWindow slices with a fixed-size ring of one-minute
+	// buckets (see metrics_ring_buffer.go), so reporting a task result is
+	// O(1) instead of appending to an ever-growing slice that then has to
+	// be linearly re-scanned to drop entries older than an hour.
+	window *metricsRingBuffer
 }
 
 // SystemMetrics holds system-related metrics
 type SystemMetrics struct {
 	MemoryUsageMB   float64
-	CPUUsagePercent float64
+	CPUUsagePercent float64 // host-wide CPU usage, sampled via cpuSampler
 	GoroutineCount  int
 	LastUpdated     time.Time
+
+	// ProcessCPUPercent is this worker process's own CPU usage - what
+	// calculateHealthStatus actually gates "degraded" on, since host CPU
+	// usage can be dominated by unrelated processes sharing the machine.
+	ProcessCPUPercent float64
+	LoadAverage1m     float64
+	OpenFDs           int
 }
 
 // NewHealthMonitor creates a new health monitor
 func NewHealthMonitor(config *config.Config, logger *logrus.Logger, redisClient *redis.Client) *HealthMonitor {
 	ctx, cancel := context.WithCancel(context.Background())
-	
-	return &HealthMonitor{
+
+	hm := &HealthMonitor{
 		config:      config,
 		logger:      logger,
 		redisClient: redisClient,
@@ -70,33 +127,62 @@ func NewHealthMonitor(config *config.Config, logger *logrus.Logger, redisClient
 		shutdownCh:  make(chan struct{}),
 		healthStatus: protocol.NewHealthStatus(config.WorkerID, protocol.ScraperType(config.ScraperType)),
 		taskMetrics: &TaskMetrics{
-			successWindow:      make([]time.Time, 0),
-			errorWindow:        make([]time.Time, 0),
-			responseTimeWindow: make([]time.Duration, 0),
+			window: newMetricsRingBuffer(),
 		},
 		systemMetrics: &SystemMetrics{},
+		peers:         make(map[string]*peerRecord),
 	}
+
+	if config.MetricsEnabled && config.MetricsAddr != "" {
+		path := config.MetricsPath
+		if path == "" {
+			path = "/metrics"
+		}
+		hm.metrics = newMetricsServer(config.MetricsAddr, path, logger)
+	}
+
+	sampler, err := newCPUSampler()
+	if err != nil {
+		logger.WithError(err).Warn("Failed to initialize CPU sampler; CPU/load/FD metrics will stay at zero")
+	} else {
+		hm.cpuSampler = sampler
+	}
+
+	return hm
 }
 
 // Start starts the health monitoring
 func (hm *HealthMonitor) Start(ctx context.Context) error {
 	hm.logger.Info("Starting health monitor")
-	
+
 	// Start health reporting goroutine
 	hm.wg.Add(1)
 	go hm.healthReportingLoop()
-	
+
 	// Start system metrics collection
 	hm.wg.Add(1)
 	go hm.systemMetricsLoop()
-	
+
+	hm.startPeerGossip()
+
+	if hm.metrics != nil {
+		hm.logger.WithField("addr", hm.config.MetricsAddr).Info("Starting Prometheus metrics server")
+		hm.metrics.start()
+	}
+
 	return nil
 }
 
 // Stop stops the health monitoring
 func (hm *HealthMonitor) Stop() error {
 	hm.logger.Info("Stopping health monitor")
-	
+
+	if hm.metrics != nil {
+		if err := hm.metrics.stop(); err != nil {
+			hm.logger.WithError(err).Warn("Error shutting down metrics server")
+		}
+	}
+
 	close(hm.shutdownCh)
 	hm.cancel()
 	
@@ -136,53 +222,89 @@ func (hm *HealthMonitor) GetHealth() *protocol.HealthStatus {
 	return &health
 }
 
+// SetHealthCheckFunc registers a callback invoked after every broker
+// connectivity probe (see checkBrokerHealth), mirroring asynq's
+// healthchecker pattern. Call before Start; not safe to change
+// concurrently with a running monitor.
+func (hm *HealthMonitor) SetHealthCheckFunc(fn HealthCheckFunc) {
+	hm.healthCheckFunc = fn
+}
+
+// checkBrokerHealth pings the Redis broker and records the result onto
+// HealthStatus, independent of task activity - so a worker that hasn't
+// had a task in an hour and a worker whose broker just went down don't
+// look the same.
+func (hm *HealthMonitor) checkBrokerHealth() {
+	err := hm.redisClient.Ping(hm.ctx)
+
+	hm.healthLock.Lock()
+	if err != nil {
+		hm.brokerConsecutiveFailures++
+		hm.healthStatus.BrokerReachable = false
+		hm.healthStatus.LastBrokerErr = err.Error()
+	} else {
+		hm.brokerConsecutiveFailures = 0
+		hm.healthStatus.BrokerReachable = true
+		hm.healthStatus.LastBrokerErr = ""
+	}
+	hm.healthLock.Unlock()
+
+	if hm.healthCheckFunc != nil {
+		hm.healthCheckFunc(err)
+	}
+}
+
 // ReportTaskSuccess reports a successful task completion
 func (hm *HealthMonitor) ReportTaskSuccess(jobsFound int, duration time.Duration) {
 	hm.metricsLock.Lock()
 	defer hm.metricsLock.Unlock()
 	
 	now := time.Now()
-	
-	// Add to success window
-	hm.taskMetrics.successWindow = append(hm.taskMetrics.successWindow, now)
-	hm.taskMetrics.responseTimeWindow = append(hm.taskMetrics.responseTimeWindow, duration)
+
+	hm.taskMetrics.window.bucketFor(now).recordSuccess(duration)
 	hm.taskMetrics.LastSuccessfulScrape = now
-	
-	// Clean old entries (older than 1 hour)
-	hm.cleanOldEntries()
-	
+
 	// Update metrics
 	hm.updateTaskMetrics()
-	
+
+	if hm.config.MetricsEnabled {
+		tasksCompletedTotal.Inc()
+		taskDurationSeconds.WithLabelValues(hm.config.ScraperType).Observe(duration.Seconds())
+	}
+
 	hm.logger.WithFields(logrus.Fields{
 		"jobs_found": jobsFound,
 		"duration":   duration,
 	}).Debug("Task success reported")
 }
 
-// ReportTaskError reports a task error
-func (hm *HealthMonitor) ReportTaskError(error string, metadata map[string]interface{}) {
+// ReportTaskError reports a task error under a protocol.ErrorCategory, so
+// HealthMonitor can tell "the site is rate-limiting us" apart from "our
+// parser broke" instead of lumping every failure into one generic
+// ErrorRateLastHour.
+func (hm *HealthMonitor) ReportTaskError(category protocol.ErrorCategory, err error, metadata map[string]interface{}) {
 	hm.metricsLock.Lock()
 	defer hm.metricsLock.Unlock()
-	
+
 	now := time.Now()
-	
-	// Add to error window
-	hm.taskMetrics.errorWindow = append(hm.taskMetrics.errorWindow, now)
-	
-	// Clean old entries
-	hm.cleanOldEntries()
-	
+
+	hm.taskMetrics.window.bucketFor(now).recordError(category)
+
 	// Update metrics
 	hm.updateTaskMetrics()
-	
+
+	if hm.config.MetricsEnabled {
+		tasksFailedTotal.WithLabelValues(hm.config.ScraperType).Inc()
+	}
+
 	hm.logger.WithFields(logrus.Fields{
-		"error":    error,
+		"category": category,
+		"error":    err,
 		"metadata": metadata,
 	}).Debug("Task error reported")
-	
+
 	// Report error to Redis
-	go hm.reportErrorToRedis(error, metadata)
+	go hm.reportErrorToRedis(category, err, metadata)
 }
 
 // IsHealthy returns whether the worker is healthy
@@ -231,9 +353,17 @@ func (hm *HealthMonitor) systemMetricsLoop() {
 
 // reportHealthStatus reports current health status to Redis
 func (hm *HealthMonitor) reportHealthStatus() {
+	// Probe broker connectivity before folding it into this tick's status.
+	hm.checkBrokerHealth()
+
 	// Update health with current metrics
 	hm.updateHealthFromMetrics()
-	
+
+	// Gossip the freshly computed status to peers of this ScraperType
+	// before pushing it to Redis, so GetClusterHealth/isQuorumDegraded stay
+	// current for whichever worker reads them next.
+	hm.publishOwnStatus()
+
 	health := hm.GetHealth()
 	healthKey := protocol.GetHealthKey(protocol.ScraperType(hm.config.ScraperType), hm.config.WorkerID)
 	
@@ -250,6 +380,7 @@ func (hm *HealthMonitor) reportHealthStatus() {
 		"completed_tasks":       health.CompletedTasksLastHour,
 		"error_rate":            health.ErrorRateLastHour,
 		"memory_usage_mb":       health.MemoryUsageMB,
+		"broker_reachable":      health.BrokerReachable,
 	}).Debug("Health status reported")
 }
 
@@ -264,10 +395,19 @@ func (hm *HealthMonitor) collectSystemMetrics() {
 	hm.systemMetrics.MemoryUsageMB = float64(memStats.Alloc) / 1024 / 1024
 	hm.systemMetrics.GoroutineCount = runtime.NumGoroutine()
 	hm.systemMetrics.LastUpdated = time.Now()
-	
-	// TODO: Implement CPU usage collection
-	// This requires platform-specific code or external libraries
-	hm.systemMetrics.CPUUsagePercent = 0.0
+
+	if hm.cpuSampler != nil {
+		sample := hm.cpuSampler.sample(hm.logger)
+		hm.systemMetrics.CPUUsagePercent = sample.HostCPUPercent
+		hm.systemMetrics.ProcessCPUPercent = sample.ProcessCPUPercent
+		hm.systemMetrics.LoadAverage1m = sample.LoadAverage1m
+		hm.systemMetrics.OpenFDs = sample.OpenFDs
+	}
+
+	if hm.config.MetricsEnabled {
+		memoryBytesGauge.Set(float64(memStats.Alloc))
+		goroutinesGauge.Set(float64(hm.systemMetrics.GoroutineCount))
+	}
 }
 
 // updateHealthFromMetrics updates health status from current metrics
@@ -285,13 +425,39 @@ func (hm *HealthMonitor) updateHealthFromMetrics() {
 	hm.healthStatus.ErrorRateLastHour = taskMetrics.ErrorRateLastHour
 	hm.healthStatus.MemoryUsageMB = systemMetrics.MemoryUsageMB
 	hm.healthStatus.CPUUsagePercent = systemMetrics.CPUUsagePercent
-	
+	hm.healthStatus.ProcessCPUPercent = systemMetrics.ProcessCPUPercent
+	hm.healthStatus.LoadAverage1m = systemMetrics.LoadAverage1m
+	hm.healthStatus.OpenFDs = systemMetrics.OpenFDs
+	hm.healthStatus.P50ResponseTimeSeconds = taskMetrics.P50ResponseTime.Seconds()
+	hm.healthStatus.P95ResponseTimeSeconds = taskMetrics.P95ResponseTime.Seconds()
+	hm.healthStatus.P99ResponseTimeSeconds = taskMetrics.P99ResponseTime.Seconds()
+	hm.healthStatus.ErrorsByCategory = taskMetrics.ErrorsByCategory
+	hm.healthStatus.SuggestedBackoffSeconds = taskMetrics.SuggestedBackoff.Seconds()
+
 	if !taskMetrics.LastSuccessfulScrape.IsZero() {
 		hm.healthStatus.LastSuccessfulScrape = taskMetrics.LastSuccessfulScrape.Format(time.RFC3339)
 	}
 	
-	// Determine overall health status
-	hm.healthStatus.Status = hm.calculateHealthStatus(taskMetrics, systemMetrics)
+	// Determine overall health status. A broker that's been unreachable
+	// for brokerUnhealthyAfter consecutive probes overrides whatever
+	// calculateHealthStatus would otherwise report from task/system
+	// metrics - those can look perfectly fine right up until the worker
+	// can't push a task result anywhere.
+	if hm.brokerConsecutiveFailures >= brokerUnhealthyAfter {
+		hm.healthStatus.Status = "unhealthy"
+	} else {
+		status := hm.calculateHealthStatus(taskMetrics, systemMetrics)
+
+		// A high error rate that ≥peerQuorumThreshold peers are also
+		// seeing looks like the site we're all scraping is down, not this
+		// worker being broken - soften the verdict so the orchestrator
+		// doesn't cycle every worker of this ScraperType at once.
+		if status == "unhealthy" && taskMetrics.ErrorRateLastHour > peerElevatedErrorRate && hm.isQuorumDegraded() {
+			status = "degraded"
+		}
+
+		hm.healthStatus.Status = status
+	}
 	hm.healthStatus.Timestamp = time.Now().UTC().Format(time.RFC3339)
 }
 
@@ -315,81 +481,99 @@ func (hm *HealthMonitor) calculateHealthStatus(taskMetrics TaskMetrics, systemMe
 		return "degraded"
 	}
 	
-	// Check CPU usage
-	if systemMetrics.CPUUsagePercent > 90 {
+	// Check CPU usage - gated on this process's own CPU time, not the
+	// host's, since a shared host can be busy with unrelated processes
+	// while this worker is perfectly healthy.
+	if systemMetrics.ProcessCPUPercent > hm.config.ProcessCPUThresholdPercent {
 		return "degraded"
 	}
-	
-	return "healthy"
-}
 
-// cleanOldEntries removes entries older than 1 hour from time windows
-func (hm *HealthMonitor) cleanOldEntries() {
-	cutoff := time.Now().Add(-time.Hour)
-	
-	// Clean success window
-	for i, t := range hm.taskMetrics.successWindow {
-		if t.After(cutoff) {
-			hm.taskMetrics.successWindow = hm.taskMetrics.successWindow[i:]
-			break
-		}
-		if i == len(hm.taskMetrics.successWindow)-1 {
-			hm.taskMetrics.successWindow = hm.taskMetrics.successWindow[:0]
+	// A rate-limit-heavy error mix means the site is throttling us, not
+	// that something here is broken - report degraded (with
+	// SuggestedBackoff already computed in updateTaskMetrics) rather than
+	// healthy, even when the overall error rate is below the thresholds
+	// above.
+	if taskMetrics.TasksFailedLastHour > 0 {
+		rateLimitFraction := float64(taskMetrics.ErrorsByCategory[protocol.CategoryRateLimit]) / float64(taskMetrics.TasksFailedLastHour)
+		if rateLimitFraction > rateLimitErrorFraction {
+			return "degraded"
 		}
 	}
-	
-	// Clean error window
-	for i, t := range hm.taskMetrics.errorWindow {
-		if t.After(cutoff) {
-			hm.taskMetrics.errorWindow = hm.taskMetrics.errorWindow[i:]
-			break
-		}
-		if i == len(hm.taskMetrics.errorWindow)-1 {
-			hm.taskMetrics.errorWindow = hm.taskMetrics.errorWindow[:0]
-		}
-	}
-	
-	// Clean response time window
-	if len(hm.taskMetrics.responseTimeWindow) > len(hm.taskMetrics.successWindow) {
-		hm.taskMetrics.responseTimeWindow = hm.taskMetrics.responseTimeWindow[len(hm.taskMetrics.responseTimeWindow)-len(hm.taskMetrics.successWindow):]
-	}
+
+	return "healthy"
 }
 
-// updateTaskMetrics recalculates task metrics from current windows
+// rateLimitErrorFraction is the share of last-hour failures attributed to
+// protocol.CategoryRateLimit above which calculateHealthStatus reports
+// "degraded" even if the overall ErrorRateLastHour is otherwise unremarkable.
+const rateLimitErrorFraction = 0.3
+
+// updateTaskMetrics recalculates task metrics from the last hour's worth of
+// ring buffer buckets - O(ringBufferBuckets) regardless of task volume,
+// unlike the linear-scan-over-unbounded-slices this used to be.
 func (hm *HealthMonitor) updateTaskMetrics() {
-	successCount := len(hm.taskMetrics.successWindow)
-	errorCount := len(hm.taskMetrics.errorWindow)
+	buckets := hm.taskMetrics.window.windowBuckets(time.Now(), time.Hour)
+
+	successCount, errorCount, totalDuration := windowTotals(buckets)
 	totalTasks := successCount + errorCount
-	
+
 	hm.taskMetrics.TasksCompletedLastHour = successCount
 	hm.taskMetrics.TasksFailedLastHour = errorCount
-	
+
 	if totalTasks > 0 {
 		hm.taskMetrics.ErrorRateLastHour = float64(errorCount) / float64(totalTasks)
 	} else {
 		hm.taskMetrics.ErrorRateLastHour = 0.0
 	}
-	
-	// Calculate average response time
-	if len(hm.taskMetrics.responseTimeWindow) > 0 {
-		var total time.Duration
-		for _, duration := range hm.taskMetrics.responseTimeWindow {
-			total += duration
-		}
-		hm.taskMetrics.AverageResponseTime = total / time.Duration(len(hm.taskMetrics.responseTimeWindow))
+
+	if successCount > 0 {
+		hm.taskMetrics.AverageResponseTime = totalDuration / time.Duration(successCount)
+	}
+
+	hm.taskMetrics.P50ResponseTime, hm.taskMetrics.P95ResponseTime, hm.taskMetrics.P99ResponseTime = windowPercentiles(buckets)
+
+	hm.taskMetrics.ErrorsByCategory = windowErrorsByCategory(buckets)
+	hm.taskMetrics.SuggestedBackoff = suggestedBackoff(hm.taskMetrics.ErrorsByCategory[protocol.CategoryRateLimit])
+
+	if hm.config.MetricsEnabled {
+		errorRateGauge.Set(hm.taskMetrics.ErrorRateLastHour)
+		avgResponseSecondsGauge.Set(hm.taskMetrics.AverageResponseTime.Seconds())
+	}
+}
+
+// rateLimitBackoffStep and maxSuggestedBackoff shape suggestedBackoff: each
+// rate-limit error in the last hour adds one step, capped at the max, so a
+// dispatcher reading HealthStatus.SuggestedBackoffSeconds backs off further
+// the more a worker keeps getting rate-limited.
+const (
+	rateLimitBackoffStep = 30 * time.Second
+	maxSuggestedBackoff  = 10 * time.Minute
+)
+
+// suggestedBackoff derives a backoff duration from the last hour's
+// CategoryRateLimit error count - zero if there haven't been any.
+func suggestedBackoff(rateLimitErrors int) time.Duration {
+	if rateLimitErrors == 0 {
+		return 0
 	}
+	backoff := time.Duration(rateLimitErrors) * rateLimitBackoffStep
+	if backoff > maxSuggestedBackoff {
+		backoff = maxSuggestedBackoff
+	}
+	return backoff
 }
 
 // reportErrorToRedis reports an error to the Redis error channel
-func (hm *HealthMonitor) reportErrorToRedis(error string, metadata map[string]interface{}) {
+func (hm *HealthMonitor) reportErrorToRedis(category protocol.ErrorCategory, taskErr error, metadata map[string]interface{}) {
 	errorReport := protocol.ErrorReport{
 		TaskID:      "", // Will be set by caller if available
 		ScraperType: protocol.ScraperType(hm.config.ScraperType),
-		Error:       error,
+		Category:    category,
+		Error:       taskErr.Error(),
 		Metadata:    metadata,
 		Timestamp:   time.Now().UTC().Format(time.RFC3339),
 	}
-	
+
 	if err := hm.redisClient.PublishResult(protocol.ChannelErrorReporting, errorReport); err != nil {
 		hm.logger.WithError(err).Error("Failed to report error to Redis")
 	}
@@ -0,0 +1,104 @@
+package worker
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+)
+
+// Prometheus collectors backing HealthMonitor's embedded /metrics endpoint.
+// These mirror the same data pushed to Redis via reportHealthStatus, just
+// in pull-friendly form - a metrics-server-style alternative to relying
+// solely on a TTL'd Redis key, which a Prometheus scrape target can't read
+// directly.
+var (
+	tasksCompletedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "jobspy_worker_tasks_completed_total",
+		Help: "Total scrape tasks this worker has completed successfully.",
+	})
+
+	tasksFailedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "jobspy_worker_tasks_failed_total",
+		Help: "Total scrape tasks this worker has failed, labeled by scraper type.",
+	}, []string{"scraper"})
+
+	errorRateGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "jobspy_worker_error_rate",
+		Help: "Rolling hourly task error rate (0-1), the same value HealthStatus.ErrorRateLastHour reports.",
+	})
+
+	memoryBytesGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "jobspy_worker_memory_bytes",
+		Help: "Current Go heap allocation (runtime.MemStats.Alloc) in bytes.",
+	})
+
+	goroutinesGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "jobspy_worker_goroutines",
+		Help: "Current goroutine count (runtime.NumGoroutine).",
+	})
+
+	avgResponseSecondsGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "jobspy_worker_avg_response_seconds",
+		Help: "Rolling hourly average task duration in seconds.",
+	})
+
+	taskDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "jobspy_worker_task_duration_seconds",
+		Help:    "Scrape task duration in seconds, labeled by scraper type, built from the same samples as the health monitor's task metrics window.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"scraper"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		tasksCompletedTotal,
+		tasksFailedTotal,
+		errorRateGauge,
+		memoryBytesGauge,
+		goroutinesGauge,
+		avgResponseSecondsGauge,
+		taskDurationSeconds,
+	)
+}
+
+// metricsServer is the embedded HTTP server HealthMonitor optionally runs
+// to serve Prometheus text format at config.MetricsPath, so an operator can
+// scrape a worker directly instead of relying only on the Redis push model.
+type metricsServer struct {
+	srv    *http.Server
+	logger *logrus.Logger
+}
+
+// newMetricsServer builds (but does not start) a metricsServer bound to
+// addr, serving the registered collectors above at path.
+func newMetricsServer(addr, path string, logger *logrus.Logger) *metricsServer {
+	mux := http.NewServeMux()
+	mux.Handle(path, promhttp.Handler())
+	return &metricsServer{
+		srv:    &http.Server{Addr: addr, Handler: mux},
+		logger: logger,
+	}
+}
+
+// start runs the server in the background. A failure after startup (port
+// already bound, etc.) is logged rather than fatal - a worker's ability to
+// scrape and push jobs doesn't depend on /metrics being reachable.
+func (m *metricsServer) start() {
+	go func() {
+		if err := m.srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			m.logger.WithError(err).Error("metrics server stopped unexpectedly")
+		}
+	}()
+}
+
+// stop gracefully shuts the server down, bounded by its own short timeout
+// so a slow scrape in flight can't hold up the rest of HealthMonitor.Stop.
+func (m *metricsServer) stop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return m.srv.Shutdown(ctx)
+}
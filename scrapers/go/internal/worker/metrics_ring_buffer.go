@@ -0,0 +1,194 @@
+package worker
+
+import (
+	"time"
+
+	"github.com/jobspy/scrapers/internal/protocol"
+)
+
+const (
+	// ringBufferBuckets is the number of one-minute buckets kept, giving a
+	// rolling one-hour window without ever growing past this fixed size.
+	ringBufferBuckets    = 60
+	ringBufferBucketSpan = time.Minute
+)
+
+// latencyHistogramBounds are the inclusive upper bounds, in seconds, of each
+// latency bucket a timeBucket tracks - sized for scraper task durations
+// (seconds to low minutes) rather than the sub-second buckets typical of an
+// HTTP handler histogram. A duration past the last bound falls into an
+// implicit +Inf bucket.
+var latencyHistogramBounds = []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120}
+
+// timeBucket aggregates everything reported within one ringBufferBucketSpan
+// window: success/error counts, summed duration (for the mean), and a
+// latency histogram (for percentiles) - all fixed-size, so recording a task
+// result is O(1) regardless of how many tasks the worker has handled.
+type timeBucket struct {
+	start            time.Time
+	successCount     int
+	errorCount       int
+	totalDuration    time.Duration
+	latencyCounts    []int // parallel to latencyHistogramBounds, plus a trailing +Inf bucket
+	errorsByCategory map[protocol.ErrorCategory]int
+}
+
+func newTimeBucket(start time.Time) *timeBucket {
+	return &timeBucket{
+		start:         start,
+		latencyCounts: make([]int, len(latencyHistogramBounds)+1),
+	}
+}
+
+func (b *timeBucket) recordSuccess(d time.Duration) {
+	b.successCount++
+	b.totalDuration += d
+	b.recordLatency(d)
+}
+
+func (b *timeBucket) recordError(category protocol.ErrorCategory) {
+	b.errorCount++
+	if b.errorsByCategory == nil {
+		b.errorsByCategory = make(map[protocol.ErrorCategory]int)
+	}
+	b.errorsByCategory[category]++
+}
+
+func (b *timeBucket) recordLatency(d time.Duration) {
+	seconds := d.Seconds()
+	for i, bound := range latencyHistogramBounds {
+		if seconds <= bound {
+			b.latencyCounts[i]++
+			return
+		}
+	}
+	b.latencyCounts[len(b.latencyCounts)-1]++
+}
+
+// metricsRingBuffer replaces the old unbounded successWindow/errorWindow/
+// responseTimeWindow slices with a fixed ring of timeBucket slots, one per
+// minute. Reporting a task result touches exactly one slot (O(1)) instead of
+// appending to an ever-growing slice that then has to be linearly re-scanned
+// on every report to drop entries older than the window.
+type metricsRingBuffer struct {
+	buckets [ringBufferBuckets]*timeBucket
+}
+
+func newMetricsRingBuffer() *metricsRingBuffer {
+	return &metricsRingBuffer{}
+}
+
+// bucketFor returns the slot t falls in, resetting it first if it was last
+// written by an earlier minute - i.e. rotating an old bucket out of the
+// window simply means overwriting it the next time its slot comes due,
+// rather than any background sweep having to find and clear it.
+func (r *metricsRingBuffer) bucketFor(t time.Time) *timeBucket {
+	bucketStart := t.Truncate(ringBufferBucketSpan)
+	slot := int(bucketStart.Unix()/int64(ringBufferBucketSpan.Seconds())) % ringBufferBuckets
+	if slot < 0 {
+		slot += ringBufferBuckets
+	}
+
+	existing := r.buckets[slot]
+	if existing == nil || !existing.start.Equal(bucketStart) {
+		existing = newTimeBucket(bucketStart)
+		r.buckets[slot] = existing
+	}
+	return existing
+}
+
+// windowBuckets returns the buckets whose start still falls within the last
+// `window` of now. Buckets that aged out are left as-is (not cleared) since
+// bucketFor will overwrite them on their next write; skipping them here is
+// just as cheap and avoids a separate sweep.
+func (r *metricsRingBuffer) windowBuckets(now time.Time, window time.Duration) []*timeBucket {
+	cutoff := now.Add(-window)
+	result := make([]*timeBucket, 0, ringBufferBuckets)
+	for _, b := range r.buckets {
+		if b != nil && b.start.After(cutoff) {
+			result = append(result, b)
+		}
+	}
+	return result
+}
+
+// windowTotals sums success/error counts and total duration across buckets.
+func windowTotals(buckets []*timeBucket) (successCount, errorCount int, totalDuration time.Duration) {
+	for _, b := range buckets {
+		successCount += b.successCount
+		errorCount += b.errorCount
+		totalDuration += b.totalDuration
+	}
+	return
+}
+
+// windowErrorsByCategory sums per-category error counts across buckets.
+func windowErrorsByCategory(buckets []*timeBucket) map[protocol.ErrorCategory]int {
+	result := make(map[protocol.ErrorCategory]int)
+	for _, b := range buckets {
+		for category, count := range b.errorsByCategory {
+			result[category] += count
+		}
+	}
+	return result
+}
+
+// windowPercentiles computes p50/p95/p99 response time across the merged
+// latency histograms of the given buckets, via the same linear-interpolation
+// approximation Prometheus's histogram_quantile uses for bucketed data.
+func windowPercentiles(buckets []*timeBucket) (p50, p95, p99 time.Duration) {
+	merged := make([]int, len(latencyHistogramBounds)+1)
+	total := 0
+	for _, b := range buckets {
+		for i, c := range b.latencyCounts {
+			merged[i] += c
+			total += c
+		}
+	}
+	if total == 0 {
+		return 0, 0, 0
+	}
+
+	return latencyQuantile(merged, total, 0.50), latencyQuantile(merged, total, 0.95), latencyQuantile(merged, total, 0.99)
+}
+
+// latencyQuantile finds the q-th quantile (0-1) across histogram bucket
+// counts, interpolating linearly within whichever bucket the target rank
+// falls in. A target landing in the trailing +Inf bucket is reported as
+// that bucket's lower bound, since it has no upper bound to interpolate to.
+func latencyQuantile(counts []int, total int, q float64) time.Duration {
+	target := q * float64(total)
+	cumulative := 0
+	lowerBound := 0.0
+
+	for i, c := range counts {
+		cumulative += c
+		if float64(cumulative) < target {
+			lowerBound = latencyBoundAt(i)
+			continue
+		}
+
+		if i == len(latencyHistogramBounds) {
+			return secondsToDuration(lowerBound)
+		}
+		upperBound := latencyHistogramBounds[i]
+		if c == 0 {
+			return secondsToDuration(upperBound)
+		}
+		rank := target - float64(cumulative-c)
+		return secondsToDuration(lowerBound + (rank/float64(c))*(upperBound-lowerBound))
+	}
+
+	return secondsToDuration(lowerBound)
+}
+
+func latencyBoundAt(i int) float64 {
+	if i < len(latencyHistogramBounds) {
+		return latencyHistogramBounds[i]
+	}
+	return latencyHistogramBounds[len(latencyHistogramBounds)-1]
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
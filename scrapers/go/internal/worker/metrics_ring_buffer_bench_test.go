@@ -0,0 +1,49 @@
+package worker
+
+import (
+	"testing"
+	"time"
+)
+
+// BenchmarkMetricsRingBuffer_RecordSuccess demonstrates that reporting a task
+// result is O(1) regardless of how many results have already been recorded:
+// each call touches exactly one bucket (overwriting it if it aged out of the
+// window) instead of appending to the unbounded slice the ring buffer
+// replaced. ns/op should stay flat as b.N grows.
+func BenchmarkMetricsRingBuffer_RecordSuccess(b *testing.B) {
+	r := newMetricsRingBuffer()
+	now := time.Now()
+	duration := 150 * time.Millisecond
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// Advance by a second each iteration so successive calls exercise
+		// bucketFor's bucket-rotation path, not just one hot bucket.
+		r.bucketFor(now.Add(time.Duration(i) * time.Second)).recordSuccess(duration)
+	}
+}
+
+// BenchmarkMetricsRingBuffer_WindowPercentiles shows that the read side is
+// bounded by ringBufferBuckets (60), not by the total number of task results
+// ever recorded: every bucket is pre-filled with a large sample count before
+// timing starts, so each reported op always merges exactly 60 histograms.
+func BenchmarkMetricsRingBuffer_WindowPercentiles(b *testing.B) {
+	r := newMetricsRingBuffer()
+	now := time.Now()
+
+	const samplesPerBucket = 10_000
+	for i := 0; i < ringBufferBuckets; i++ {
+		bucket := r.bucketFor(now.Add(time.Duration(i) * time.Minute))
+		for j := 0; j < samplesPerBucket; j++ {
+			bucket.recordSuccess(time.Duration(j%5000) * time.Millisecond)
+		}
+	}
+
+	windowEnd := now.Add(time.Duration(ringBufferBuckets-1) * time.Minute)
+	buckets := r.windowBuckets(windowEnd, time.Hour)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		windowPercentiles(buckets)
+	}
+}
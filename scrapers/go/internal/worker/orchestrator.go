@@ -2,13 +2,12 @@ package worker
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/sirupsen/logrus"
-	"github.com/google/uuid"
 
 	"github.com/jobspy/scrapers/internal/config"
 	"github.com/jobspy/scrapers/internal/protocol"
@@ -16,24 +15,52 @@ import (
 	"github.com/jobspy/scrapers/internal/scraper"
 )
 
+// retryBackoffBase and retryBackoffMax bound the delayed-retry schedule:
+// a failed task is re-enqueued no sooner than retryBackoffBase and no later
+// than retryBackoffMax plus ComputeBackoff's jitter of up to 20% after its
+// RetryCount-th failure (see redis.ComputeBackoff).
+const (
+	retryBackoffBase = 2 * time.Second
+	retryBackoffMax  = 2 * time.Minute
+)
+
+// retryDispatchInterval is how often the RetryDispatcher polls the delayed-
+// retry ZSET for due tasks.
+const retryDispatchInterval = 1 * time.Second
+
+// periodicDispatchInterval is how often the PeriodicDispatcher polls the
+// periodic-job schedule ZSET for due cron firings. Coarser than
+// retryDispatchInterval since cron expressions are minute-granularity at
+// best.
+const periodicDispatchInterval = 5 * time.Second
+
 // Orchestrator manages multiple worker goroutines for job scraping
 type Orchestrator struct {
-	config       *config.Config
-	logger       *logrus.Logger
-	redisClient  *redis.Client
-	scraperFactory ScraperFactory
-	workers      []*Worker
-	healthMonitor *HealthMonitor
-	
+	config             *config.Config
+	logger             *logrus.Logger
+	redisClient        *redis.Client
+	scraperFactory     ScraperFactory
+	workers            []*Worker
+	healthMonitor      *HealthMonitor
+	scheduler          *Scheduler
+	configWatcher      *config.Watcher
+	retryDispatcher    *redis.RetryDispatcher
+	periodicDispatcher *redis.PeriodicDispatcher
+
 	// Control channels
-	ctx          context.Context
-	cancel       context.CancelFunc
-	shutdownCh   chan struct{}
-	wg           sync.WaitGroup
-	
+	ctx        context.Context
+	cancel     context.CancelFunc
+	shutdownCh chan struct{}
+	wg         sync.WaitGroup
+
 	// Metrics
-	metrics      *OrchestratorMetrics
-	metricsLock  sync.RWMutex
+	metrics     *OrchestratorMetrics
+	metricsLock sync.RWMutex
+
+	// Weighted fair-queueing credits for the urgent/normal/backfill priority
+	// queues, refilled from protocol.PriorityWeights every second.
+	priorityCredits map[protocol.TaskPriority]int
+	creditsLock     sync.Mutex
 }
 
 // OrchestratorMetrics holds orchestrator-level metrics
@@ -59,7 +86,7 @@ type ScraperFactory interface {
 // NewOrchestrator creates a new worker orchestrator
 func NewOrchestrator(cfg *config.Config, logger *logrus.Logger, redisClient *redis.Client, factory ScraperFactory) *Orchestrator {
 	ctx, cancel := context.WithCancel(context.Background())
-	
+
 	orchestrator := &Orchestrator{
 		config:         cfg,
 		logger:         logger,
@@ -69,24 +96,38 @@ func NewOrchestrator(cfg *config.Config, logger *logrus.Logger, redisClient *red
 		cancel:         cancel,
 		shutdownCh:     make(chan struct{}),
 		metrics: &OrchestratorMetrics{
-			StartTime:     time.Now(),
-			TotalWorkers:  cfg.Concurrency,
+			StartTime:    time.Now(),
+			TotalWorkers: cfg.Concurrency,
 		},
+		priorityCredits: initialPriorityCredits(),
 	}
-	
+
 	// Initialize health monitor
 	orchestrator.healthMonitor = NewHealthMonitor(cfg, logger, redisClient)
-	
+
+	// Initialize distributed scheduler (leader election + worker discovery)
+	orchestrator.scheduler = NewScheduler(cfg, logger, redisClient)
+
 	return orchestrator
 }
 
+// initialPriorityCredits returns a fresh copy of the weighted fair-queueing
+// credit balances, seeded from protocol.PriorityWeights.
+func initialPriorityCredits() map[protocol.TaskPriority]int {
+	credits := make(map[protocol.TaskPriority]int, len(protocol.PriorityWeights))
+	for priority, weight := range protocol.PriorityWeights {
+		credits[priority] = weight
+	}
+	return credits
+}
+
 // Start starts the orchestrator and all worker goroutines
 func (o *Orchestrator) Start() error {
 	o.logger.WithFields(logrus.Fields{
-		"worker_id":     o.config.WorkerID,
-		"scraper_type":  o.config.ScraperType,
-		"concurrency":   o.config.Concurrency,
-		"region":        o.config.Region,
+		"worker_id":    o.config.WorkerID,
+		"scraper_type": o.config.ScraperType,
+		"concurrency":  o.config.Concurrency,
+		"region":       o.config.Region,
 	}).Info("Starting scraper orchestrator")
 
 	// Validate configuration
@@ -94,6 +135,17 @@ func (o *Orchestrator) Start() error {
 		return fmt.Errorf("configuration validation failed: %w", err)
 	}
 
+	// When running on the Streams backend, make sure the consumer group
+	// exists before any worker tries to read from it.
+	if redis.QueueBackend(o.config.QueueBackend) == redis.QueueBackendStream {
+		scraperType := protocol.ScraperType(o.config.ScraperType)
+		stream := protocol.GetTaskQueue(scraperType)
+		group := protocol.GetConsumerGroup(scraperType)
+		if err := o.redisClient.EnsureConsumerGroup(stream, group); err != nil {
+			return fmt.Errorf("failed to ensure consumer group: %w", err)
+		}
+	}
+
 	// Start health monitor
 	if err := o.healthMonitor.Start(o.ctx); err != nil {
 		return fmt.Errorf("failed to start health monitor: %w", err)
@@ -102,18 +154,54 @@ func (o *Orchestrator) Start() error {
 	// Start metrics collection
 	o.startMetricsCollection()
 
+	// Start the distributed scheduler: heartbeat publishing so the leader
+	// can discover this worker, and leader-election campaigning.
+	o.scheduler.Start(o.ctx, func() int64 { return o.GetMetrics().TasksProcessed }, func() int {
+		metrics := o.GetMetrics()
+		return int(metrics.TasksProcessed - metrics.TasksSuccessful - metrics.TasksFailed)
+	})
+
+	// Start priority-credit refill loop (weighted fair-queueing)
+	o.startCreditRefill()
+
+	// Start the delayed-retry dispatcher so tasks scheduled via
+	// ScheduleRetry actually get re-enqueued once due.
+	o.retryDispatcher = redis.NewRetryDispatcher(o.redisClient, o.logger, protocol.ScraperType(o.config.ScraperType), redis.QueueBackend(o.config.QueueBackend), retryDispatchInterval)
+	o.retryDispatcher.Start(o.ctx)
+
+	// Start the periodic-job dispatcher. Its schedule ZSET is global (not
+	// scoped to this worker's scraper type), so every Orchestrator process
+	// in the fleet runs one and they race harmlessly over claimDuePeriodicJobs'
+	// atomic claim.
+	o.periodicDispatcher = redis.NewPeriodicDispatcher(o.redisClient, o.logger, redis.QueueBackend(o.config.QueueBackend), periodicDispatchInterval)
+	o.periodicDispatcher.Start(o.ctx)
+
+	// Attach a config file watcher for hot-reload, if we're actually running
+	// off a config file (env-only deployments have nothing to watch).
+	if o.config.ConfigFilePath != "" {
+		watcher, err := config.NewWatcher(o.config.ConfigFilePath, o.config, o.logger)
+		if err != nil {
+			o.logger.WithError(err).Warn("Failed to start config watcher, hot-reload disabled")
+		} else {
+			o.configWatcher = watcher
+			go watcher.Run()
+			o.wg.Add(1)
+			go o.consumeConfigReloads()
+		}
+	}
+
 	// Create and start workers
 	o.workers = make([]*Worker, o.config.Concurrency)
 	for i := 0; i < o.config.Concurrency; i++ {
 		workerConfig := o.createWorkerConfig(i)
-		
+
 		worker, err := NewWorker(workerConfig, o.logger, o.redisClient, o.scraperFactory)
 		if err != nil {
 			return fmt.Errorf("failed to create worker %d: %w", i, err)
 		}
-		
+
 		o.workers[i] = worker
-		
+
 		// Start worker in goroutine
 		o.wg.Add(1)
 		go o.runWorker(worker, i)
@@ -130,39 +218,57 @@ func (o *Orchestrator) Start() error {
 // Stop gracefully stops the orchestrator and all workers
 func (o *Orchestrator) Stop() error {
 	o.logger.Info("Stopping scraper orchestrator...")
-	
+
 	// Signal shutdown
 	close(o.shutdownCh)
 	o.cancel()
-	
+
 	// Wait for all workers to complete with timeout
 	done := make(chan struct{})
 	go func() {
 		o.wg.Wait()
 		close(done)
 	}()
-	
+
 	select {
 	case <-done:
 		o.logger.Info("All workers stopped gracefully")
 	case <-time.After(30 * time.Second):
 		o.logger.Warn("Timeout waiting for workers to stop, forcing shutdown")
 	}
-	
+
 	// Stop health monitor
 	if err := o.healthMonitor.Stop(); err != nil {
 		o.logger.WithError(err).Error("Error stopping health monitor")
 	}
-	
+
+	// Stop distributed scheduler (cancel via o.ctx already fired above)
+	o.scheduler.Stop()
+
+	// Stop the retry dispatcher
+	if o.retryDispatcher != nil {
+		o.retryDispatcher.Stop()
+	}
+
+	// Stop the periodic-job dispatcher
+	if o.periodicDispatcher != nil {
+		o.periodicDispatcher.Stop()
+	}
+
+	// Stop config watcher, if one was attached
+	if o.configWatcher != nil {
+		o.configWatcher.Stop()
+	}
+
 	// Close Redis client
 	if err := o.redisClient.Close(); err != nil {
 		o.logger.WithError(err).Error("Error closing Redis client")
 	}
-	
+
 	o.updateMetrics(func(m *OrchestratorMetrics) {
 		m.ActiveWorkers = 0
 	})
-	
+
 	o.logger.Info("Scraper orchestrator stopped")
 	return nil
 }
@@ -171,7 +277,7 @@ func (o *Orchestrator) Stop() error {
 func (o *Orchestrator) GetMetrics() *OrchestratorMetrics {
 	o.metricsLock.RLock()
 	defer o.metricsLock.RUnlock()
-	
+
 	// Create a copy to avoid race conditions
 	metrics := *o.metrics
 	return &metrics
@@ -180,37 +286,74 @@ func (o *Orchestrator) GetMetrics() *OrchestratorMetrics {
 // GetHealthStatus returns current health status
 func (o *Orchestrator) GetHealthStatus() *protocol.HealthStatus {
 	metrics := o.GetMetrics()
-	
+
 	status := "healthy"
 	if metrics.ActiveWorkers == 0 {
 		status = "unhealthy"
 	} else if metrics.ErrorRate > 0.5 {
 		status = "degraded"
 	}
-	
+
+	systemHealth := o.healthMonitor.GetHealth()
+
 	return &protocol.HealthStatus{
-		WorkerID:                 o.config.WorkerID,
-		ScraperType:              protocol.ScraperType(o.config.ScraperType),
-		Status:                   status,
-		ActiveTasks:              int(metrics.TasksProcessed - metrics.TasksSuccessful - metrics.TasksFailed),
-		CompletedTasksLastHour:   int(metrics.TasksSuccessful),
-		ErrorRateLastHour:        metrics.ErrorRate,
-		MemoryUsageMB:            0, // TODO: Implement memory monitoring
-		CPUUsagePercent:          0, // TODO: Implement CPU monitoring
-		ProxyPoolSize:            len(o.config.ProxyPool),
-		ProxySuccessRate:         100.0, // TODO: Implement proxy monitoring
-		LastSuccessfulScrape:     metrics.LastTaskProcessed.Format(time.RFC3339),
-		Timestamp:                time.Now().UTC().Format(time.RFC3339),
+		WorkerID:               o.config.WorkerID,
+		ScraperType:            protocol.ScraperType(o.config.ScraperType),
+		Status:                 status,
+		ActiveTasks:            int(metrics.TasksProcessed - metrics.TasksSuccessful - metrics.TasksFailed),
+		CompletedTasksLastHour: int(metrics.TasksSuccessful),
+		ErrorRateLastHour:      metrics.ErrorRate,
+		MemoryUsageMB:          systemHealth.MemoryUsageMB,
+		CPUUsagePercent:        systemHealth.CPUUsagePercent,
+		ProxyPoolSize:          len(o.config.ProxyPool),
+		ProxySuccessRate:       o.averageProxySuccessRate(),
+		LastSuccessfulScrape:   metrics.LastTaskProcessed.Format(time.RFC3339),
+		Timestamp:              time.Now().UTC().Format(time.RFC3339),
 	}
 }
 
+// averageProxySuccessRate averages ProxySuccessRate across this
+// orchestrator's workers (each sourced from its scraper's own tracked
+// proxy stats, see jobspy_client.healthStatus), falling back to
+// protocol.NewHealthStatus's default of 100.0 ("no data yet") when there
+// are no workers to sample.
+func (o *Orchestrator) averageProxySuccessRate() float64 {
+	if len(o.workers) == 0 {
+		return 100.0
+	}
+
+	var sum float64
+	for _, w := range o.workers {
+		sum += w.GetHealthStatus().ProxySuccessRate
+	}
+	return sum / float64(len(o.workers))
+}
+
 // runWorker runs a single worker until shutdown
 func (o *Orchestrator) runWorker(worker *Worker, workerIndex int) {
 	defer o.wg.Done()
-	
+
 	workerLogger := o.logger.WithField("worker_index", workerIndex)
 	workerLogger.Info("Starting worker")
-	
+
+	// Stagger each worker's first poll by a deterministic fraction of the
+	// polling interval so N concurrent workers don't all issue their first
+	// PopTask in the same instant — the same thundering-herd avoidance
+	// Prometheus uses when staggering scrape targets.
+	if o.config.Concurrency > 1 {
+		interval := time.Duration(o.config.QueueTimeout) * time.Second
+		offset := time.Duration(workerIndex) * interval / time.Duration(o.config.Concurrency)
+		workerLogger.WithField("stagger_offset", offset).Debug("Staggering initial poll")
+
+		select {
+		case <-time.After(offset):
+		case <-o.shutdownCh:
+			return
+		case <-o.ctx.Done():
+			return
+		}
+	}
+
 	for {
 		select {
 		case <-o.shutdownCh:
@@ -221,16 +364,16 @@ func (o *Orchestrator) runWorker(worker *Worker, workerIndex int) {
 			return
 		default:
 		}
-		
+
 		// Process next task
 		if err := o.processNextTask(worker, workerLogger); err != nil {
 			if err == context.DeadlineExceeded || err == context.Canceled {
 				workerLogger.Debug("Worker task cancelled")
 				continue
 			}
-			
+
 			workerLogger.WithError(err).Error("Error processing task")
-			
+
 			// Add delay before retrying on error
 			select {
 			case <-time.After(5 * time.Second):
@@ -241,71 +384,195 @@ func (o *Orchestrator) runWorker(worker *Worker, workerIndex int) {
 	}
 }
 
-// processNextTask processes the next available task
+// processNextTask processes the next available task. On the list backend
+// this draws from the urgent/normal/backfill priority queues proportionally
+// to their current weighted fair-queueing credits; on the Streams backend
+// it reads from the scraper type's single consumer group, which tracks the
+// task as pending until it's explicitly acknowledged.
 func (o *Orchestrator) processNextTask(worker *Worker, logger *logrus.Entry) error {
-	// Get task queue for this scraper type
-	queueName := protocol.GetTaskQueue(protocol.ScraperType(o.config.ScraperType))
-	
-	// Pop task with timeout
+	scraperType := protocol.ScraperType(o.config.ScraperType)
+	backend := redis.QueueBackend(o.config.QueueBackend)
+
 	var task protocol.ScrapingTask
-	taskAvailable, err := o.redisClient.PopTask(queueName, time.Duration(o.config.QueueTimeout)*time.Second, &task)
-	if err != nil {
-		return fmt.Errorf("failed to pop task from queue: %w", err)
+	var queue string
+	var taskAvailable bool
+	var err error
+
+	switch backend {
+	case redis.QueueBackendStream:
+		stream := protocol.GetTaskQueue(scraperType)
+		group := protocol.GetConsumerGroup(scraperType)
+		queue = stream
+		taskAvailable, task.StreamMessageID, err = o.redisClient.PopTaskStream(stream, group, worker.config.WorkerID, time.Duration(o.config.QueueTimeout)*time.Second, &task)
+		if err != nil {
+			return fmt.Errorf("failed to pop task from stream %s: %w", stream, err)
+		}
+	case redis.QueueBackendPriority:
+		// Single ZSET per scraper type, ordered by ScrapingTask.Priority
+		// (then FIFO) - see priority_queue.go. No weighted fair-queueing
+		// credits here; the priority field itself decides dequeue order.
+		queue = protocol.GetTaskQueue(scraperType)
+		taskAvailable, err = o.redisClient.PopPrioritizedTask(queue, time.Duration(o.config.QueueTimeout)*time.Second, &task)
+		if err != nil {
+			return fmt.Errorf("failed to pop task from priority queue %s: %w", queue, err)
+		}
+	default:
+		queues, weights := o.priorityQueuesByCredit(scraperType)
+		taskAvailable, queue, err = o.redisClient.PopTaskMulti(queues, weights, time.Duration(o.config.QueueTimeout)*time.Second, &task)
+		if err != nil {
+			return fmt.Errorf("failed to pop task from priority queues: %w", err)
+		}
 	}
-	
+
 	if !taskAvailable {
 		// No task available, continue polling
 		return nil
 	}
-	
+
+	if backend == redis.QueueBackendList {
+		o.spendPriorityCredit(queue)
+	}
+
 	// Update metrics
 	o.updateMetrics(func(m *OrchestratorMetrics) {
 		m.TasksProcessed++
 		m.LastTaskProcessed = time.Now()
 	})
-	
+
 	logger = logger.WithFields(logrus.Fields{
 		"task_id":      task.TaskID,
 		"scraper_type": task.ScraperType,
 		"search_term":  task.Params.SearchTerm,
 		"location":     task.Params.Location,
 	})
-	
+
 	logger.Info("Processing scraping task")
-	
-	// Create context with timeout
+
+	// Two-phase cancellation: notCanceled is soft-cancelled if the worker
+	// misses heartbeats for UpdateInterval (e.g. a hung attempt), giving the
+	// in-flight HTTP call a chance to unwind on its own context.Done(). If
+	// the task still hasn't finished after ForceCancelInterval, the
+	// orchestrator abandons it outright rather than hold the worker slot
+	// forever — the goroutine is left to exit on its own in the background.
 	taskCtx, cancel := context.WithTimeout(o.ctx, time.Duration(task.Timeout)*time.Second)
 	defer cancel()
-	
-	// Process task
+
+	notCanceled, softCancel := context.WithCancel(taskCtx)
+	defer softCancel()
+
+	var lastHeartbeat int64
+	atomic.StoreInt64(&lastHeartbeat, time.Now().UnixNano())
+	worker.SetHeartbeatCallback(func() {
+		atomic.StoreInt64(&lastHeartbeat, time.Now().UnixNano())
+	})
+	defer worker.SetHeartbeatCallback(nil)
+
+	type taskOutcome struct {
+		result *protocol.ScrapingResult
+		err    error
+	}
+	resultCh := make(chan taskOutcome, 1)
+
 	startTime := time.Now()
-	result, err := worker.ProcessTask(taskCtx, &task)
+	go func() {
+		result, err := worker.ProcessTask(notCanceled, &task)
+		resultCh <- taskOutcome{result: result, err: err}
+	}()
+
+	updateInterval := worker.config.UpdateInterval
+	forceCancelInterval := worker.config.ForceCancelInterval
+
+	softTimer := time.NewTimer(updateInterval)
+	defer softTimer.Stop()
+	hardTimer := time.NewTimer(forceCancelInterval)
+	defer hardTimer.Stop()
+
+	var outcome taskOutcome
+	abandoned := false
+
+waitLoop:
+	for {
+		select {
+		case outcome = <-resultCh:
+			break waitLoop
+		case <-softTimer.C:
+			if time.Since(time.Unix(0, atomic.LoadInt64(&lastHeartbeat))) >= updateInterval {
+				logger.WithField("update_interval", updateInterval).Warn("Worker missed heartbeat deadline, issuing soft cancel")
+				softCancel()
+			}
+			softTimer.Reset(updateInterval)
+		case <-hardTimer.C:
+			logger.WithField("force_cancel_interval", forceCancelInterval).Warn("Worker exceeded force-cancel deadline, abandoning task")
+			abandoned = true
+			break waitLoop
+		}
+	}
+
+	if abandoned {
+		// Let the orphaned goroutine finish (or never return) without
+		// blocking this worker slot; just log what eventually happens.
+		go func() {
+			out := <-resultCh
+			logger.WithField("task_id", task.TaskID).WithError(out.err).Debug("Abandoned task goroutine finally completed")
+		}()
+		return o.handleAbandonedTask(&task, queue, backend, logger)
+	}
+
 	duration := time.Since(startTime)
-	
+	result, err := outcome.result, outcome.err
+
 	if err != nil {
 		logger.WithError(err).WithField("duration", duration).Error("Task processing failed")
-		
+
 		// Update failure metrics
 		o.updateMetrics(func(m *OrchestratorMetrics) {
 			m.TasksFailed++
 			m.ErrorRate = float64(m.TasksFailed) / float64(m.TasksProcessed)
 		})
-		
-		// Send error result
-		result = &protocol.ScrapingResult{
-			TaskID:        task.TaskID,
-			Status:        protocol.TaskStatusFailed,
-			ScraperType:   task.ScraperType,
-			ExecutionTime: duration.Seconds(),
-			CompletedAt:   time.Now().UTC().Format(time.RFC3339),
-			Error:         &err.Error(),
+
+		errMsg := err.Error()
+		if task.RetryCount < task.MaxRetries {
+			task.RetryCount++
+			delay := redis.ComputeBackoff(retryBackoffBase, retryBackoffMax, task.RetryCount)
+			if scheduleErr := o.redisClient.ScheduleRetry(queue, &task, time.Now().UTC().Add(delay)); scheduleErr != nil {
+				logger.WithError(scheduleErr).Error("Failed to schedule task retry, falling back to permanent failure")
+			} else {
+				logger.WithFields(logrus.Fields{"retry_count": task.RetryCount, "delay": delay}).Warn("Task failed, scheduled for delayed retry")
+				result = &protocol.ScrapingResult{
+					TaskID:        task.TaskID,
+					Status:        protocol.TaskStatusRetry,
+					ScraperType:   task.ScraperType,
+					ExecutionTime: duration.Seconds(),
+					CompletedAt:   time.Now().UTC().Format(time.RFC3339),
+					Error:         &errMsg,
+				}
+			}
+		}
+
+		if result == nil {
+			// Retries exhausted (or scheduling failed): this is a terminal
+			// failure, so park the task on the dead-letter queue for manual
+			// inspection rather than letting it vanish.
+			deadLetterQueue := protocol.GetDeadLetterQueue(scraperType)
+			if dlqErr := o.redisClient.PushTask(deadLetterQueue, &task); dlqErr != nil {
+				logger.WithError(dlqErr).Error("Failed to dead-letter exhausted task")
+			}
+
+			result = &protocol.ScrapingResult{
+				TaskID:        task.TaskID,
+				Status:        protocol.TaskStatusFailed,
+				ScraperType:   task.ScraperType,
+				ExecutionTime: duration.Seconds(),
+				CompletedAt:   time.Now().UTC().Format(time.RFC3339),
+				Error:         &errMsg,
+			}
 		}
 	} else {
 		logger.WithFields(logrus.Fields{
 			"duration":   duration,
 			"jobs_found": result.JobsFound,
 		}).Info("Task completed successfully")
-		
+
 		// Update success metrics
 		o.updateMetrics(func(m *OrchestratorMetrics) {
 			m.TasksSuccessful++
@@ -313,30 +580,116 @@ func (o *Orchestrator) processNextTask(worker *Worker, logger *logrus.Entry) err
 			m.ErrorRate = float64(m.TasksFailed) / float64(m.TasksProcessed)
 		})
 	}
-	
+
 	// Ensure task ID is set
 	result.TaskID = task.TaskID
-	
+
 	// Publish result
 	resultsQueue := protocol.ChannelScrapingResults
 	if err := o.redisClient.PublishResult(resultsQueue, result); err != nil {
 		logger.WithError(err).Error("Failed to publish task result")
 		return fmt.Errorf("failed to publish result: %w", err)
 	}
-	
+
 	logger.Debug("Task result published successfully")
+
+	// Only now that the result is durably published do we acknowledge the
+	// stream message; if the process crashed between popping and here, the
+	// task stays pending for ClaimStalePending to reassign instead of being
+	// silently lost.
+	if backend == redis.QueueBackendStream && task.StreamMessageID != "" {
+		group := protocol.GetConsumerGroup(scraperType)
+		if err := o.redisClient.AckTask(queue, group, task.StreamMessageID); err != nil {
+			logger.WithError(err).Error("Failed to acknowledge stream task")
+			return fmt.Errorf("failed to ack stream task: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// handleAbandonedTask is called when a task exceeds ForceCancelInterval
+// without completing. On the list and priority backends it requeues the
+// task (incrementing RetryCount) if attempts remain, or publishes a final
+// timeout result otherwise; on the Streams backend it leaves the task
+// pending for reclaim. Either way it frees the worker slot without waiting
+// on the hung goroutine.
+func (o *Orchestrator) handleAbandonedTask(task *protocol.ScrapingTask, queue string, backend redis.QueueBackend, logger *logrus.Entry) error {
+	o.updateMetrics(func(m *OrchestratorMetrics) {
+		m.TasksTimeout++
+		m.TasksFailed++
+		m.ErrorRate = float64(m.TasksFailed) / float64(m.TasksProcessed)
+	})
+
+	// On the Streams backend an abandoned task is simply left un-acked: it
+	// stays in the consumer group's pending entries list and
+	// ClaimStalePending reassigns it to a live worker once it's been
+	// outstanding longer than ForceCancelInterval. Explicitly requeuing it
+	// here too would double-process it once reclaimed.
+	if backend == redis.QueueBackendStream {
+		logger.Warn("Abandoned stream task left pending for reclaim by ClaimStalePending")
+		return nil
+	}
+
+	if task.RetryCount < task.MaxRetries {
+		task.RetryCount++
+
+		var requeueErr error
+		if backend == redis.QueueBackendPriority {
+			requeueErr = o.redisClient.PushPrioritizedTask(queue, task)
+		} else {
+			requeueErr = o.redisClient.PushTask(queue, task)
+		}
+		if requeueErr != nil {
+			logger.WithError(requeueErr).Error("Failed to requeue abandoned task")
+			return fmt.Errorf("failed to requeue abandoned task: %w", requeueErr)
+		}
+		logger.WithField("retry_count", task.RetryCount).Warn("Abandoned task requeued for retry")
+		return nil
+	}
+
+	errMsg := "task abandoned after exceeding force-cancel deadline without completing (retries exhausted)"
+	result := &protocol.ScrapingResult{
+		TaskID:      task.TaskID,
+		Status:      protocol.TaskStatusTimeout,
+		ScraperType: task.ScraperType,
+		CompletedAt: time.Now().UTC().Format(time.RFC3339),
+		Error:       &errMsg,
+	}
+	if err := o.redisClient.PublishResult(protocol.ChannelScrapingResults, result); err != nil {
+		logger.WithError(err).Error("Failed to publish abandoned task result")
+		return fmt.Errorf("failed to publish abandoned task result: %w", err)
+	}
+
+	logger.Warn("Abandoned task exhausted retries, published failed result")
 	return nil
 }
 
 // createWorkerConfig creates configuration for a worker
 func (o *Orchestrator) createWorkerConfig(index int) *WorkerConfig {
 	return &WorkerConfig{
-		WorkerID:       fmt.Sprintf("%s-worker-%d", o.config.WorkerID, index),
-		ScraperType:    o.config.ScraperType,
-		MaxRetries:     o.config.MaxRetries,
-		RetryDelay:     time.Duration(o.config.RetryDelay) * time.Second,
-		TaskTimeout:    time.Duration(o.config.TaskTimeout) * time.Second,
-		MetricsEnabled: o.config.MetricsEnabled,
+		WorkerID:              fmt.Sprintf("%s-worker-%d", o.config.WorkerID, index),
+		ScraperType:           o.config.ScraperType,
+		Region:                o.config.Region,
+		Backend:               o.config.ScraperBackend,
+		ScrapeRulesDir:        o.config.ScrapeRulesDir,
+		ProxyProviderType:     o.config.ProxyProviderType,
+		ProxySource:           o.config.ProxySource,
+		ProxyRotationStrategy: o.config.ProxyRotationStrategy,
+		ProxyHealthCheckURL:   o.config.ProxyHealthCheckURL,
+		ProxyPollInterval:     o.config.ProxyPollInterval,
+		RateLimitRPM:          o.config.RateLimitRPM,
+		RespectRetryAfter:     o.config.RespectRetryAfter,
+		JobSpyAPIURL:          o.config.JobSpyAPIURL,
+		MaxRetries:            o.config.MaxRetries,
+		RetryDelay:            time.Duration(o.config.RetryDelay) * time.Second,
+		TaskTimeout:           time.Duration(o.config.TaskTimeout) * time.Second,
+		MetricsEnabled:        o.config.MetricsEnabled,
+		UpdateInterval:        time.Duration(o.config.UpdateInterval) * time.Second,
+		ForceCancelInterval:   time.Duration(o.config.ForceCancelInterval) * time.Second,
+		DispatchInterval:      o.config.ScrapeDispatchInterval,
+		OutputFormats:         o.config.OutputFormats,
+		OutputDir:             o.config.OutputDir,
 	}
 }
 
@@ -345,15 +698,15 @@ func (o *Orchestrator) validateConfig() error {
 	if o.config.ScraperType == "" {
 		return fmt.Errorf("scraper_type is required")
 	}
-	
+
 	if !protocol.IsValidScraperType(o.config.ScraperType) {
 		return fmt.Errorf("invalid scraper_type: %s", o.config.ScraperType)
 	}
-	
+
 	if o.config.Concurrency <= 0 || o.config.Concurrency > 100 {
 		return fmt.Errorf("concurrency must be between 1 and 100")
 	}
-	
+
 	return nil
 }
 
@@ -362,14 +715,14 @@ func (o *Orchestrator) startMetricsCollection() {
 	if !o.config.MetricsEnabled {
 		return
 	}
-	
+
 	o.wg.Add(1)
 	go func() {
 		defer o.wg.Done()
-		
+
 		ticker := time.NewTicker(o.config.MetricsInterval)
 		defer ticker.Stop()
-		
+
 		for {
 			select {
 			case <-ticker.C:
@@ -387,10 +740,10 @@ func (o *Orchestrator) startMetricsCollection() {
 func (o *Orchestrator) reportMetrics() {
 	metrics := o.GetMetrics()
 	healthStatus := o.GetHealthStatus()
-	
+
 	// Report to health monitor
 	o.healthMonitor.UpdateHealth(healthStatus)
-	
+
 	// Log metrics
 	o.logger.WithFields(logrus.Fields{
 		"tasks_processed":   metrics.TasksProcessed,
@@ -407,4 +760,123 @@ func (o *Orchestrator) updateMetrics(updateFunc func(*OrchestratorMetrics)) {
 	o.metricsLock.Lock()
 	defer o.metricsLock.Unlock()
 	updateFunc(o.metrics)
-}
\ No newline at end of file
+}
+
+// priorityQueuesByCredit returns the per-scraper-type priority queue names
+// paired with each queue's remaining credit, in protocol.AllPriorities
+// order. PopTaskMulti uses the credit as its weight, so an exhausted queue
+// (credit 0) is only probed after queues that still have budget, giving the
+// 8:4:1 urgent:normal:backfill ratio without starving any band.
+func (o *Orchestrator) priorityQueuesByCredit(scraperType protocol.ScraperType) ([]string, []int) {
+	o.creditsLock.Lock()
+	defer o.creditsLock.Unlock()
+
+	queues := make([]string, 0, len(protocol.AllPriorities))
+	weights := make([]int, 0, len(protocol.AllPriorities))
+	for _, priority := range protocol.AllPriorities {
+		queues = append(queues, protocol.GetPriorityQueue(scraperType, priority))
+		weights = append(weights, o.priorityCredits[priority]+1) // +1 so a drained queue is still probed, just last
+	}
+	return queues, weights
+}
+
+// spendPriorityCredit decrements the credit balance of the priority queue a
+// task was popped from.
+func (o *Orchestrator) spendPriorityCredit(queue string) {
+	o.creditsLock.Lock()
+	defer o.creditsLock.Unlock()
+
+	for _, priority := range protocol.AllPriorities {
+		if queue == protocol.GetPriorityQueue(protocol.ScraperType(o.config.ScraperType), priority) {
+			if o.priorityCredits[priority] > 0 {
+				o.priorityCredits[priority]--
+			}
+			return
+		}
+	}
+}
+
+// consumeConfigReloads applies every config the watcher delivers until
+// shutdown. It runs for the lifetime of the orchestrator.
+func (o *Orchestrator) consumeConfigReloads() {
+	defer o.wg.Done()
+
+	for {
+		select {
+		case newConfig, ok := <-o.configWatcher.Updates():
+			if !ok {
+				return
+			}
+			o.applyConfigReload(newConfig)
+		case <-o.shutdownCh:
+			return
+		case <-o.ctx.Done():
+			return
+		}
+	}
+}
+
+// applyConfigReload pushes a hot-reloaded configuration's mutable fields
+// into the running orchestrator config and every worker's scraper, without
+// restarting any worker goroutine.
+func (o *Orchestrator) applyConfigReload(newConfig *config.Config) {
+	o.config = newConfig
+
+	if level, err := logrus.ParseLevel(newConfig.LogLevel); err == nil {
+		o.logger.SetLevel(level)
+	}
+
+	reloadCfg := scraper.ScraperConfig{
+		ProxyPool:        newConfig.ProxyPool,
+		UserAgents:       newConfig.UserAgents,
+		MinDelay:         newConfig.MinDelay,
+		MaxDelay:         newConfig.MaxDelay,
+		RotateProxies:    newConfig.RotateProxies,
+		RotateUserAgents: newConfig.RotateUserAgents,
+		ScrapeRulesDir:   newConfig.ScrapeRulesDir,
+
+		ProxyProviderType:     newConfig.ProxyProviderType,
+		ProxySource:           newConfig.ProxySource,
+		ProxyRotationStrategy: newConfig.ProxyRotationStrategy,
+		ProxyHealthCheckURL:   newConfig.ProxyHealthCheckURL,
+		ProxyPollInterval:     newConfig.ProxyPollInterval,
+	}
+
+	for _, worker := range o.workers {
+		if worker == nil || worker.scraper == nil {
+			continue
+		}
+		if err := worker.scraper.ReloadConfig(reloadCfg); err != nil {
+			o.logger.WithError(err).WithField("worker_id", worker.config.WorkerID).Error("Failed to apply config reload to worker's scraper")
+		}
+	}
+
+	o.logger.WithField("config_reloads", o.configWatcher.Stats()).Info("Applied hot-reloaded configuration to all workers")
+}
+
+// startCreditRefill refills the weighted fair-queueing credits back to
+// protocol.PriorityWeights once per second.
+func (o *Orchestrator) startCreditRefill() {
+	o.wg.Add(1)
+	go func() {
+		defer o.wg.Done()
+
+		ticker := time.NewTicker(1 * time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				o.creditsLock.Lock()
+				for priority, weight := range protocol.PriorityWeights {
+					o.priorityCredits[priority] = weight
+				}
+				o.creditsLock.Unlock()
+			case <-o.shutdownCh:
+				return
+			case <-o.ctx.Done():
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,147 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/sirupsen/logrus"
+
+	"github.com/jobspy/scrapers/internal/config"
+	"github.com/jobspy/scrapers/internal/protocol"
+	"github.com/jobspy/scrapers/internal/redis"
+	"github.com/jobspy/scrapers/internal/scraper"
+)
+
+// blockingScraper's ScrapeJobs hangs forever, ignoring ctx entirely, to
+// simulate a scraper backend that's genuinely hung rather than just slow -
+// exactly the case processNextTask's hard-cancel (ForceCancelInterval) has to
+// recover from regardless of what the soft-cancel's heartbeat deadline does.
+type blockingScraper struct {
+	block chan struct{}
+}
+
+func newBlockingScraper() *blockingScraper {
+	return &blockingScraper{block: make(chan struct{})}
+}
+
+func (s *blockingScraper) GetName() string                    { return "blocking" }
+func (s *blockingScraper) GetType() protocol.ScraperType       { return protocol.ScraperTypeIndeed }
+func (s *blockingScraper) Configure(scraper.ScraperConfig) error   { return nil }
+func (s *blockingScraper) ReloadConfig(scraper.ScraperConfig) error { return nil }
+func (s *blockingScraper) ValidateParams(protocol.ScrapingTaskParams) error { return nil }
+func (s *blockingScraper) Close() error                        { return nil }
+func (s *blockingScraper) GetHealthStatus() *protocol.HealthStatus {
+	return protocol.NewHealthStatus("blocking-worker", protocol.ScraperTypeIndeed)
+}
+
+func (s *blockingScraper) ScrapeJobs(ctx context.Context, params protocol.ScrapingTaskParams) (*protocol.ScrapingResult, error) {
+	<-s.block
+	return nil, nil
+}
+
+type fakeScraperFactory struct {
+	scraper scraper.Scraper
+}
+
+func (f *fakeScraperFactory) CreateScraper(protocol.ScraperType, scraper.ScraperConfig) (scraper.Scraper, error) {
+	return f.scraper, nil
+}
+
+func (f *fakeScraperFactory) GetSupportedTypes() []protocol.ScraperType {
+	return []protocol.ScraperType{protocol.ScraperTypeIndeed}
+}
+
+// TestProcessNextTask_FreesWorkerOnHungScrape reproduces the scenario behind
+// the heartbeat/soft-cancel fix in startAttemptHeartbeat: a scraper whose
+// ScrapeJobs call never returns and never observes ctx.Done(). Without a
+// hard ceiling, processNextTask would block its caller forever; with it, the
+// worker slot must free up within ForceCancelInterval (plus the loop's own
+// scheduling slack), no matter how the soft-cancel behaves.
+func TestProcessNextTask_FreesWorkerOnHungScrape(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	redisConfig := redis.DefaultConfig()
+	redisConfig.URL = "redis://" + mr.Addr()
+	redisClient, err := redis.NewClient(redisConfig, logger)
+	if err != nil {
+		t.Fatalf("failed to create redis client: %v", err)
+	}
+	defer redisClient.Close()
+
+	const forceCancelInterval = 2 * time.Second
+
+	cfg := config.DefaultConfig()
+	cfg.ScraperType = string(protocol.ScraperTypeIndeed)
+	cfg.Concurrency = 1
+	cfg.QueueBackend = "list"
+	cfg.UpdateInterval = 1
+	cfg.ForceCancelInterval = int(forceCancelInterval.Seconds())
+	// Disable dispatch staggering so the attempt enters executeScrape (and
+	// blockingScraper.ScrapeJobs) immediately instead of waiting out
+	// ScrapeScheduler's stagger window first.
+	cfg.ScrapeDispatchInterval = 0
+
+	fakeScraper := newBlockingScraper()
+	factory := &fakeScraperFactory{scraper: fakeScraper}
+
+	orch := NewOrchestrator(cfg, logger, redisClient, factory)
+
+	workerConfig := orch.createWorkerConfig(0)
+	w, err := NewWorker(workerConfig, logger, redisClient, factory)
+	if err != nil {
+		t.Fatalf("failed to create worker: %v", err)
+	}
+	defer w.Close()
+
+	task := &protocol.ScrapingTask{
+		TaskID:      "stall-test-task",
+		ScraperType: protocol.ScraperTypeIndeed,
+		Params: protocol.ScrapingTaskParams{
+			SearchTerm:    "golang",
+			Location:      "remote",
+			ResultsWanted: 10,
+		},
+		Timeout:    30,
+		MaxRetries: 0,
+	}
+
+	queue := protocol.GetPriorityQueue(protocol.ScraperTypeIndeed, protocol.PriorityNormal)
+	if err := redisClient.PushTask(queue, task); err != nil {
+		t.Fatalf("failed to push task: %v", err)
+	}
+
+	entry := logger.WithField("test", "stall")
+
+	updateInterval := time.Duration(cfg.UpdateInterval) * time.Second
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- orch.processNextTask(w, entry)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("processNextTask returned an error: %v", err)
+		}
+		// blockingScraper ignores ctx, so the soft-cancel (which does fire
+		// here, UpdateInterval being below heartbeatTickFloor) cannot end
+		// the call; only the forceCancelInterval abandon can, and that
+		// never comes before updateInterval.
+		if elapsed := time.Since(start); elapsed < updateInterval {
+			t.Fatalf("processNextTask returned after only %v, before even UpdateInterval (%v) elapsed - the attempt was cancelled too early", elapsed, updateInterval)
+		}
+	case <-time.After(forceCancelInterval + 3*time.Second):
+		t.Fatalf("processNextTask did not free the worker within ForceCancelInterval (%v) plus slack", forceCancelInterval)
+	}
+}
@@ -0,0 +1,166 @@
+package worker
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jobspy/scrapers/internal/protocol"
+)
+
+// peerQuorumThreshold is how many peers of the same ScraperType must also
+// be reporting an elevated error rate before this worker's own high error
+// rate is treated as a systemic upstream issue (degraded) rather than a
+// worker-local fault (unhealthy) - see isQuorumDegraded.
+const peerQuorumThreshold = 2
+
+// peerElevatedErrorRate is the ErrorRateLastHour a peer must report to
+// count toward quorum in isQuorumDegraded - the same threshold
+// calculateHealthStatus itself uses to call a worker "degraded".
+const peerElevatedErrorRate = 0.5
+
+// peerRecord is one peer's most recently gossiped HealthStatus plus when it
+// arrived, so a peer that stops publishing (crashed, partitioned) can be
+// evicted from GetClusterHealth/isQuorumDegraded instead of being counted
+// forever.
+type peerRecord struct {
+	status   *protocol.HealthStatus
+	lastSeen time.Time
+}
+
+// ClusterHealth is the aggregate peer health GetClusterHealth reports,
+// covering this worker and every not-yet-stale peer of the same
+// ScraperType it's heard from over gossip.
+type ClusterHealth struct {
+	TotalPeers       int
+	HealthyCount     int
+	DegradedCount    int
+	UnhealthyCount   int
+	ClusterErrorRate float64
+}
+
+// startPeerGossip subscribes to this ScraperType's peer channel (see
+// protocol.GetPeerHealthChannel). Inspired by Traffic Monitor's peer-based
+// optimistic health: workers of the same type compare reported error rates
+// so a site-wide outage reads as "degraded" cluster-wide instead of
+// triggering every worker's own "unhealthy" verdict at once.
+func (hm *HealthMonitor) startPeerGossip() {
+	channel := protocol.GetPeerHealthChannel(protocol.ScraperType(hm.config.ScraperType))
+	pubsub := hm.redisClient.Subscribe(channel)
+
+	hm.wg.Add(1)
+	go func() {
+		defer hm.wg.Done()
+		defer pubsub.Close()
+
+		msgCh := pubsub.Channel()
+		for {
+			select {
+			case msg, ok := <-msgCh:
+				if !ok {
+					return
+				}
+				hm.handlePeerMessage(msg.Payload)
+			case <-hm.shutdownCh:
+				return
+			case <-hm.ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// handlePeerMessage decodes a gossiped HealthStatus and records it, unless
+// it's this worker's own broadcast echoing back.
+func (hm *HealthMonitor) handlePeerMessage(payload string) {
+	var status protocol.HealthStatus
+	if err := json.Unmarshal([]byte(payload), &status); err != nil {
+		hm.logger.WithError(err).Warn("Failed to decode peer health gossip message")
+		return
+	}
+
+	if status.WorkerID == hm.config.WorkerID {
+		return
+	}
+
+	hm.peersLock.Lock()
+	hm.peers[status.WorkerID] = &peerRecord{status: &status, lastSeen: time.Now()}
+	hm.peersLock.Unlock()
+}
+
+// publishOwnStatus gossips this worker's current status to its peers. Best
+// effort, like reportErrorToRedis: a failed publish is logged, not retried.
+func (hm *HealthMonitor) publishOwnStatus() {
+	health := hm.GetHealth()
+	channel := protocol.GetPeerHealthChannel(protocol.ScraperType(hm.config.ScraperType))
+	if err := hm.redisClient.Publish(channel, health); err != nil {
+		hm.logger.WithError(err).Warn("Failed to publish health status to peers")
+	}
+}
+
+// evictStalePeersLocked drops peers whose last gossip predates
+// config.PeerStalenessTimeout. Caller must hold peersLock.
+func (hm *HealthMonitor) evictStalePeersLocked() {
+	cutoff := time.Now().Add(-hm.config.PeerStalenessTimeout)
+	for id, p := range hm.peers {
+		if p.lastSeen.Before(cutoff) {
+			delete(hm.peers, id)
+		}
+	}
+}
+
+// GetClusterHealth aggregates this worker's own status with every
+// not-yet-stale peer of the same ScraperType.
+func (hm *HealthMonitor) GetClusterHealth() ClusterHealth {
+	self := hm.GetHealth()
+
+	hm.peersLock.Lock()
+	hm.evictStalePeersLocked()
+	statuses := make([]*protocol.HealthStatus, 0, len(hm.peers)+1)
+	for _, p := range hm.peers {
+		statuses = append(statuses, p.status)
+	}
+	hm.peersLock.Unlock()
+
+	statuses = append(statuses, self)
+
+	var cluster ClusterHealth
+	var errorRateSum float64
+	for _, s := range statuses {
+		cluster.TotalPeers++
+		switch s.Status {
+		case "healthy":
+			cluster.HealthyCount++
+		case "degraded":
+			cluster.DegradedCount++
+		default:
+			cluster.UnhealthyCount++
+		}
+		errorRateSum += s.ErrorRateLastHour
+	}
+	if cluster.TotalPeers > 0 {
+		cluster.ClusterErrorRate = errorRateSum / float64(cluster.TotalPeers)
+	}
+
+	return cluster
+}
+
+// isQuorumDegraded reports whether at least peerQuorumThreshold peers are
+// also seeing an elevated error rate right now - if so, this worker's own
+// high error rate most likely reflects a site-wide outage rather than a
+// worker-local fault, and calculateHealthStatus's "unhealthy" verdict
+// should be softened to "degraded" so the orchestrator doesn't cycle every
+// worker of this ScraperType at once.
+func (hm *HealthMonitor) isQuorumDegraded() bool {
+	hm.peersLock.Lock()
+	defer hm.peersLock.Unlock()
+
+	hm.evictStalePeersLocked()
+
+	elevated := 0
+	for _, p := range hm.peers {
+		if p.status.ErrorRateLastHour > peerElevatedErrorRate {
+			elevated++
+		}
+	}
+	return elevated >= peerQuorumThreshold
+}
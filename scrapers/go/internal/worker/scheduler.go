@@ -0,0 +1,236 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/jobspy/scrapers/internal/config"
+	"github.com/jobspy/scrapers/internal/protocol"
+	"github.com/jobspy/scrapers/internal/redis"
+)
+
+// leaderLeaseTTL is the TTL of the scheduler leader lease. It is renewed at
+// ttl/3, so a leader that misses two renewals in a row loses leadership.
+const leaderLeaseTTL = 15 * time.Second
+
+// heartbeatInterval is how often each worker publishes a WorkerHeartbeat.
+const heartbeatInterval = 5 * time.Second
+
+// heartbeatTTL is three heartbeat intervals (15s), so a worker that dies
+// mid-cycle disappears from discovery within that time rather than lingering.
+const heartbeatTTL = heartbeatInterval * 3
+
+// staleClaimMinIdle is the shortest time a Streams-backend task must sit
+// unacknowledged before the leader reassigns it via ClaimStalePending -
+// comfortably longer than a single heartbeat miss. reclaimStalePending
+// waits ForceCancelInterval instead when that is longer, so a task still
+// within its force-cancel deadline is not taken from its worker.
+const staleClaimMinIdle = heartbeatTTL
+
+// Scheduler discovers live workers via Redis heartbeats and, while holding
+// leadership, distributes load across shards (per scraper type and target
+// site) so no single worker or priority queue starves the others. Only the
+// elected leader performs distribution; every worker (leader or not)
+// publishes its own heartbeat so discovery stays accurate.
+type Scheduler struct {
+	config      *config.Config
+	logger      *logrus.Logger
+	redisClient *redis.Client
+	elector     *redis.LeaderElector
+
+	metricsLock  sync.RWMutex
+	workerCounts map[string]int64 // worker_id -> tasks handled, leader-observed
+
+	wg sync.WaitGroup
+}
+
+// NewScheduler creates a new distributed scheduler.
+func NewScheduler(cfg *config.Config, logger *logrus.Logger, redisClient *redis.Client) *Scheduler {
+	return &Scheduler{
+		config:       cfg,
+		logger:       logger,
+		redisClient:  redisClient,
+		elector:      redis.NewLeaderElector(redisClient, protocol.ChannelSchedulerLeader+":"+cfg.ScraperType, leaderLeaseTTL, logger),
+		workerCounts: make(map[string]int64),
+	}
+}
+
+// Start launches the heartbeat publisher and the leader-election campaign.
+// Both run until ctx is cancelled.
+func (s *Scheduler) Start(ctx context.Context, tasksHandled func() int64, activeTasks func() int) {
+	s.wg.Add(1)
+	go s.heartbeatLoop(ctx, tasksHandled, activeTasks)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.elector.Campaign(ctx, s.onElected, s.onDemoted)
+	}()
+}
+
+// Stop waits for the scheduler's background goroutines to exit. Callers
+// should cancel the context passed to Start before calling Stop.
+func (s *Scheduler) Stop() {
+	s.wg.Wait()
+}
+
+// IsLeader reports whether this process currently holds scheduler
+// leadership for its scraper type.
+func (s *Scheduler) IsLeader() bool {
+	return s.elector.IsLeader()
+}
+
+// GetWorkerCounts returns a snapshot of per-worker task counts as last
+// observed by the leader. Empty (and stale) on non-leader instances.
+func (s *Scheduler) GetWorkerCounts() map[string]int64 {
+	s.metricsLock.RLock()
+	defer s.metricsLock.RUnlock()
+
+	counts := make(map[string]int64, len(s.workerCounts))
+	for k, v := range s.workerCounts {
+		counts[k] = v
+	}
+	return counts
+}
+
+func (s *Scheduler) heartbeatLoop(ctx context.Context, tasksHandled func() int64, activeTasks func() int) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+
+	publish := func() {
+		heartbeat := protocol.WorkerHeartbeat{
+			WorkerID:     s.config.WorkerID,
+			ScraperType:  s.config.ScraperType,
+			Region:       s.config.Region,
+			ActiveTasks:  activeTasks(),
+			TasksHandled: tasksHandled(),
+			Timestamp:    time.Now().UTC().Format(time.RFC3339),
+		}
+		key := protocol.GetHeartbeatKey(s.config.WorkerID)
+		if err := s.redisClient.SetHeartbeat(key, heartbeat, heartbeatTTL); err != nil {
+			s.logger.WithError(err).Warn("Failed to publish worker heartbeat")
+		}
+	}
+
+	publish()
+	for {
+		select {
+		case <-ticker.C:
+			publish()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// onElected starts the leader-only rebalancing loop. It is invoked
+// synchronously from the election tick, so it must not block.
+func (s *Scheduler) onElected() {
+	s.wg.Add(1)
+	go s.rebalanceLoop()
+}
+
+// onDemoted is invoked when leadership is lost; the rebalance loop notices
+// via s.elector.IsLeader() and exits on its next tick.
+func (s *Scheduler) onDemoted() {
+	s.logger.Debug("Scheduler leadership relinquished, rebalance loop will stop")
+}
+
+// rebalanceLoop periodically fetches every live worker's heartbeat and
+// updates the observed per-worker task counts so imbalances across the
+// fleet are visible in GetWorkerCounts/metrics. It exits as soon as
+// leadership is lost.
+func (s *Scheduler) rebalanceLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+
+	for s.elector.IsLeader() {
+		<-ticker.C
+		if !s.elector.IsLeader() {
+			return
+		}
+		s.collectWorkerCounts()
+		s.reclaimStalePending()
+	}
+}
+
+// reclaimStalePending sweeps the scraper type's consumer group for messages
+// that have outlived ForceCancelInterval (or staleClaimMinIdle, whichever
+// is longer) without being acknowledged - almost
+// always the sign of a worker that died mid-scrape - and reassigns them to
+// this (leader) process so they aren't lost. A no-op on the list backend.
+func (s *Scheduler) reclaimStalePending() {
+	if redis.QueueBackend(s.config.QueueBackend) != redis.QueueBackendStream {
+		return
+	}
+
+	scraperType := protocol.ScraperType(s.config.ScraperType)
+	stream := protocol.GetTaskQueue(scraperType)
+	group := protocol.GetConsumerGroup(scraperType)
+
+	minIdle := time.Duration(s.config.ForceCancelInterval) * time.Second
+	if minIdle < staleClaimMinIdle {
+		minIdle = staleClaimMinIdle
+	}
+	claimed, err := s.redisClient.ClaimStalePending(stream, group, minIdle, s.config.WorkerID)
+	if err != nil {
+		s.logger.WithError(err).Warn("Leader failed to sweep stale pending stream tasks")
+		return
+	}
+
+	for _, msg := range claimed {
+		var task protocol.ScrapingTask
+		if err := json.Unmarshal(msg.Task, &task); err != nil {
+			s.logger.WithError(err).WithField("message_id", msg.MessageID).Warn("Failed to decode reclaimed stream task, leaving it claimed for manual inspection")
+			continue
+		}
+		task.RetryCount++
+
+		if task.RetryCount > task.MaxRetries {
+			s.logger.WithField("task_id", task.TaskID).Warn("Reclaimed stream task exhausted retries, acknowledging without resubmission")
+			if err := s.redisClient.AckTask(stream, group, msg.MessageID); err != nil {
+				s.logger.WithError(err).Warn("Failed to ack exhausted reclaimed task")
+			}
+			continue
+		}
+
+		if _, err := s.redisClient.PushTaskStream(stream, &task); err != nil {
+			s.logger.WithError(err).WithField("task_id", task.TaskID).Warn("Failed to resubmit reclaimed stream task")
+			continue
+		}
+		if err := s.redisClient.AckTask(stream, group, msg.MessageID); err != nil {
+			s.logger.WithError(err).Warn("Failed to ack original entry for resubmitted reclaimed task")
+		}
+	}
+}
+
+func (s *Scheduler) collectWorkerCounts() {
+	pattern := protocol.ChannelWorkerHeartbeat + ":*"
+	keys, err := s.redisClient.GetAllHealthKeys(pattern)
+	if err != nil {
+		s.logger.WithError(err).Warn("Leader failed to list worker heartbeats")
+		return
+	}
+
+	counts := make(map[string]int64, len(keys))
+	for _, key := range keys {
+		var heartbeat protocol.WorkerHeartbeat
+		found, err := s.redisClient.GetHealth(key, &heartbeat)
+		if err != nil || !found {
+			continue
+		}
+		counts[heartbeat.WorkerID] = heartbeat.TasksHandled
+	}
+
+	s.metricsLock.Lock()
+	s.workerCounts = counts
+	s.metricsLock.Unlock()
+}
@@ -0,0 +1,108 @@
+package worker
+
+import (
+	"context"
+	"hash/fnv"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// scrapeDispatchJitterDivisor is the N in the stagger formula
+// rand(0, interval/N): it adds a small random term on top of the
+// deterministic per-key phase offset so workers sharing a key don't all
+// wake at exactly the same instant.
+const scrapeDispatchJitterDivisor = 4
+
+// scrapeIntervalSeconds tracks the actual wall-clock gap between
+// successive scrape dispatches for the same site, so drift from the
+// configured stagger interval is visible alongside whether the dispatch
+// it preceded ultimately succeeded or failed.
+var scrapeIntervalSeconds = prometheus.NewSummaryVec(
+	prometheus.SummaryOpts{
+		Name:       "scrape_interval_length_seconds",
+		Help:       "Observed gap between successive scrape dispatches for the same site, labeled by outcome.",
+		Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+	},
+	[]string{"site", "outcome"},
+)
+
+func init() {
+	prometheus.MustRegister(scrapeIntervalSeconds)
+}
+
+// ScrapeScheduler staggers the dispatch of tasks against the same target
+// site so many workers don't all begin scraping it at once - the
+// thundering-herd problem a batch enqueue (or a worker fleet restart)
+// would otherwise create. For each key it computes a deterministic phase
+// offset within interval from the key's hash (the same phase-then-jitter
+// shape redis.taskPhaseOffset uses for recurring tasks), plus a small
+// random jitter term so concurrent workers sharing a key still spread out.
+type ScrapeScheduler struct {
+	interval time.Duration
+
+	mu           sync.Mutex
+	lastDispatch map[string]time.Time
+}
+
+// NewScrapeScheduler builds a ScrapeScheduler staggering dispatch within
+// the given interval. A zero or negative interval disables staggering.
+func NewScrapeScheduler(interval time.Duration) *ScrapeScheduler {
+	return &ScrapeScheduler{
+		interval:     interval,
+		lastDispatch: make(map[string]time.Time),
+	}
+}
+
+// Wait blocks for key's staggered offset (or returns early with ctx.Err()
+// if cancelled first), then returns the actual gap since key's previous
+// dispatch - the interval length a caller should pass to RecordOutcome
+// once the dispatched scrape's outcome is known.
+func (s *ScrapeScheduler) Wait(ctx context.Context, key string) (time.Duration, error) {
+	if delay := s.offset(key); delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+
+	s.mu.Lock()
+	now := time.Now()
+	gap := s.interval
+	if last, ok := s.lastDispatch[key]; ok {
+		gap = now.Sub(last)
+	}
+	s.lastDispatch[key] = now
+	s.mu.Unlock()
+
+	return gap, nil
+}
+
+func (s *ScrapeScheduler) offset(key string) time.Duration {
+	if s.interval <= 0 {
+		return 0
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	phase := time.Duration(h.Sum64() % uint64(s.interval))
+
+	jitter := time.Duration(0)
+	if jitterSpan := int64(s.interval) / scrapeDispatchJitterDivisor; jitterSpan > 0 {
+		jitter = time.Duration(rand.Int63n(jitterSpan))
+	}
+
+	return phase + jitter
+}
+
+// RecordOutcome reports the dispatch-interval length Wait returned for
+// site, labeled by outcome (e.g. a protocol.TaskStatus string).
+func (s *ScrapeScheduler) RecordOutcome(site string, gap time.Duration, outcome string) {
+	scrapeIntervalSeconds.WithLabelValues(site, outcome).Observe(gap.Seconds())
+}
@@ -0,0 +1,202 @@
+package worker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/jobspy/scrapers/internal/protocol"
+	"github.com/jobspy/scrapers/internal/redis"
+)
+
+// logBufferInterval is how often buffered log lines are flushed to Redis if
+// the buffer hasn't already been flushed by hitting maxLogBufferSize.
+const logBufferInterval = 250 * time.Millisecond
+
+// maxLogBufferSize forces an early flush so a chatty scraper doesn't hold
+// unbounded log lines in memory between ticks.
+const maxLogBufferSize = 50
+
+// TaskUpdater publishes incremental TaskUpdate messages for a running task.
+// Implementations must guarantee that a terminal (completed/failed) update
+// is always the last message sent, even if it races with a buffered log
+// flush.
+type TaskUpdater interface {
+	SendProgress(pagesScraped int, batch []protocol.JobData)
+	AppendLog(line string)
+	SendHeartbeat()
+	SendTerminal(status protocol.TaskStatus, err error)
+	Close() error
+}
+
+// RedisTaskUpdater implements TaskUpdater against Redis pub/sub. Log lines
+// are buffered and flushed on a timer or when the buffer fills; a mutex
+// guards the buffer and a "closed" flag so that once a terminal update has
+// been sent, no further publish can be interleaved after it.
+type RedisTaskUpdater struct {
+	client  *redis.Client
+	channel string
+	taskID  string
+	logger  *logrus.Entry
+
+	mu        sync.Mutex
+	logBuffer []string
+	closed    bool
+	stopCh    chan struct{}
+	doneCh    chan struct{}
+}
+
+// NewRedisTaskUpdater creates a task updater for the given task and starts
+// its background log-flushing loop.
+func NewRedisTaskUpdater(client *redis.Client, taskID string, logger *logrus.Entry) *RedisTaskUpdater {
+	u := &RedisTaskUpdater{
+		client:  client,
+		channel: protocol.GetTaskUpdateChannel(taskID),
+		taskID:  taskID,
+		logger:  logger,
+		stopCh:  make(chan struct{}),
+		doneCh:  make(chan struct{}),
+	}
+	go u.flushLoop()
+	return u
+}
+
+// SendProgress publishes a progress update carrying pages scraped so far and
+// a partial batch of job data.
+func (u *RedisTaskUpdater) SendProgress(pagesScraped int, batch []protocol.JobData) {
+	u.publish(protocol.TaskUpdate{
+		TaskID:       u.taskID,
+		Type:         protocol.TaskUpdateProgress,
+		PagesScraped: pagesScraped,
+		JobsBatch:    batch,
+	})
+}
+
+// AppendLog buffers a structured log line for the next flush.
+func (u *RedisTaskUpdater) AppendLog(line string) {
+	u.mu.Lock()
+	if u.closed {
+		u.mu.Unlock()
+		return
+	}
+	u.logBuffer = append(u.logBuffer, line)
+	shouldFlush := len(u.logBuffer) >= maxLogBufferSize
+	u.mu.Unlock()
+
+	if shouldFlush {
+		u.flush()
+	}
+}
+
+// SendHeartbeat publishes a heartbeat update so a watching submitter knows
+// the task is still alive even without new progress.
+func (u *RedisTaskUpdater) SendHeartbeat() {
+	u.publish(protocol.TaskUpdate{
+		TaskID: u.taskID,
+		Type:   protocol.TaskUpdateHeartbeat,
+	})
+}
+
+// SendTerminal flushes any buffered log lines, publishes the terminal
+// completed/failed update, and marks the updater closed so no further
+// update can follow it.
+func (u *RedisTaskUpdater) SendTerminal(status protocol.TaskStatus, err error) {
+	u.mu.Lock()
+	if u.closed {
+		u.mu.Unlock()
+		return
+	}
+
+	lines := u.logBuffer
+	u.logBuffer = nil
+
+	update := protocol.TaskUpdate{
+		TaskID:    u.taskID,
+		LogLines:  lines,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	}
+	if status == protocol.TaskStatusFailed {
+		update.Type = protocol.TaskUpdateFailed
+	} else {
+		update.Type = protocol.TaskUpdateCompleted
+	}
+	if err != nil {
+		msg := err.Error()
+		update.Error = &msg
+	}
+
+	u.closed = true
+	u.publishRaw(update)
+	u.mu.Unlock()
+
+	close(u.stopCh)
+	<-u.doneCh
+}
+
+// Close stops the background flush loop without sending a terminal update.
+// Safe to call after SendTerminal has already closed the updater.
+func (u *RedisTaskUpdater) Close() error {
+	u.mu.Lock()
+	alreadyClosed := u.closed
+	u.closed = true
+	u.mu.Unlock()
+
+	if alreadyClosed {
+		return nil
+	}
+
+	close(u.stopCh)
+	<-u.doneCh
+	return nil
+}
+
+func (u *RedisTaskUpdater) flushLoop() {
+	defer close(u.doneCh)
+
+	ticker := time.NewTicker(logBufferInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			u.flush()
+		case <-u.stopCh:
+			return
+		}
+	}
+}
+
+func (u *RedisTaskUpdater) flush() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.closed || len(u.logBuffer) == 0 {
+		return
+	}
+	lines := u.logBuffer
+	u.logBuffer = nil
+
+	u.publishRaw(protocol.TaskUpdate{
+		TaskID:    u.taskID,
+		Type:      protocol.TaskUpdateLog,
+		LogLines:  lines,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+func (u *RedisTaskUpdater) publish(update protocol.TaskUpdate) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.closed {
+		return
+	}
+
+	update.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	u.publishRaw(update)
+}
+
+func (u *RedisTaskUpdater) publishRaw(update protocol.TaskUpdate) {
+	if err := u.client.Publish(u.channel, update); err != nil {
+		u.logger.WithError(err).Warn("Failed to publish task update")
+	}
+}
@@ -3,34 +3,145 @@ package worker
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
-	"github.com/google/uuid"
 
+	"github.com/jobspy/scrapers/internal/output"
 	"github.com/jobspy/scrapers/internal/protocol"
 	"github.com/jobspy/scrapers/internal/redis"
 	"github.com/jobspy/scrapers/internal/scraper"
 )
 
+// jobHashTTL is how long a published job's hash is remembered for dedupJobs
+// - long enough to catch a re-scrape of the same search within the same
+// day without growing the seen-jobs Set unboundedly.
+const jobHashTTL = 24 * time.Hour
+
 // Worker represents a single scraping worker
 type Worker struct {
-	config         *WorkerConfig
-	logger         *logrus.Entry
-	redisClient    *redis.Client
-	scraperFactory ScraperFactory
-	scraper        scraper.Scraper
-	metrics        *WorkerMetrics
+	config          *WorkerConfig
+	logger          *logrus.Entry
+	redisClient     *redis.Client
+	scraperFactory  ScraperFactory
+	scraper         scraper.Scraper
+	metrics         *WorkerMetrics
+	scrapeScheduler *ScrapeScheduler
+
+	heartbeatMu sync.Mutex
+	heartbeatFn func()
+}
+
+// SetHeartbeatCallback registers a function invoked at the start of each
+// scrape attempt and periodically while it runs (see startAttemptHeartbeat),
+// letting the orchestrator detect a stalled worker (one that hasn't fired
+// it within UpdateInterval) without the
+// worker needing to know about two-phase cancellation itself. Pass nil to
+// clear the callback.
+func (w *Worker) SetHeartbeatCallback(fn func()) {
+	w.heartbeatMu.Lock()
+	defer w.heartbeatMu.Unlock()
+	w.heartbeatFn = fn
+}
+
+func (w *Worker) fireHeartbeat() {
+	w.heartbeatMu.Lock()
+	fn := w.heartbeatFn
+	w.heartbeatMu.Unlock()
+	if fn != nil {
+		fn()
+	}
+}
+
+// heartbeatTickFloor is the shortest period startAttemptHeartbeat will tick
+// at, used when WorkerConfig.UpdateInterval is zero or small enough that
+// halving it would make the ticker needlessly chatty.
+const heartbeatTickFloor = 5 * time.Second
+
+// startAttemptHeartbeat fires the heartbeat callback on a fixed interval for
+// as long as a single attempt is in flight. fireHeartbeat is otherwise only
+// called once, at the start of an attempt - without this, a single
+// long-running but healthy attempt (e.g. an opaque, non-streaming
+// ScrapeJobs call) goes quiet until the next retry, and the orchestrator's
+// soft-cancel mistakes that silence for a stall once UpdateInterval elapses,
+// even well within TaskTimeout. Ticking at half of UpdateInterval keeps
+// lastHeartbeat fresh enough that the soft-cancel's "time.Since(lastHeartbeat)
+// >= updateInterval" check never trips for a healthy attempt, as long as
+// UpdateInterval is longer than heartbeatTickFloor; at or below that, the
+// floor makes the ticker no faster than UpdateInterval and the check can
+// still trip. Call the returned stop func once the attempt completes.
+func (w *Worker) startAttemptHeartbeat() (stop func()) {
+	interval := w.config.UpdateInterval / 2
+	if interval < heartbeatTickFloor {
+		interval = heartbeatTickFloor
+	}
+
+	stopCh := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				w.fireHeartbeat()
+			case <-stopCh:
+				return
+			}
+		}
+	}()
+	return func() {
+		close(stopCh)
+		<-done
+	}
 }
 
 // WorkerConfig holds configuration for a worker
 type WorkerConfig struct {
 	WorkerID       string
 	ScraperType    string
+	Region         string // fed into protocol.ComputeTaskHash; otherwise informational
+	Backend        string // scraper backend to construct, see scraper.Register; empty defaults to "jobspy"
+	ScrapeRulesDir string // directory of scraper/rules.Rule files; empty disables rule-based extraction
+
+	// Dynamic proxy pool (see scraper/proxy), forwarded to ScraperConfig as-is.
+	ProxyProviderType     string
+	ProxySource           string
+	ProxyRotationStrategy string
+	ProxyHealthCheckURL   string
+	ProxyPollInterval     time.Duration
+
+	// RateLimitRPM/RespectRetryAfter are forwarded to ScraperConfig as-is,
+	// seeding and configuring the scraper's ratelimit.AdaptiveLimiter.
+	RateLimitRPM      int
+	RespectRetryAfter bool
+
+	// JobSpyAPIURL is forwarded to ScraperConfig.BaseURL as-is; empty falls
+	// back to JobSpyAPIClient's own "http://localhost:8000" default.
+	JobSpyAPIURL string
+
 	MaxRetries     int
 	RetryDelay     time.Duration
 	TaskTimeout    time.Duration
 	MetricsEnabled bool
+	StreamUpdates  bool // publish incremental TaskUpdate messages while a task runs
+
+	// Two-phase cancellation deadlines, see Orchestrator.processNextTask.
+	UpdateInterval      time.Duration
+	ForceCancelInterval time.Duration
+
+	// DispatchInterval is the stagger window passed to ScrapeScheduler.
+	// Zero disables dispatch staggering.
+	DispatchInterval time.Duration
+
+	// OutputFormats/OutputDir configure a per-task output.SinkSet (see
+	// newOutputSinks). OutputFormats empty or OutputDir unset disables file
+	// output entirely - jobs are still published to Redis as normal.
+	OutputFormats []string
+	OutputDir     string
 }
 
 // WorkerMetrics holds worker-level metrics
@@ -53,8 +164,19 @@ func NewWorker(config *WorkerConfig, logger *logrus.Logger, redisClient *redis.C
 
 	// Create scraper instance
 	scraperConfig := scraper.ScraperConfig{
-		WorkerID:      config.WorkerID,
-		Timeout:       config.TaskTimeout,
+		WorkerID:              config.WorkerID,
+		BaseURL:               config.JobSpyAPIURL,
+		Timeout:               config.TaskTimeout,
+		Backend:               config.Backend,
+		ScrapeRulesDir:        config.ScrapeRulesDir,
+		ProxyProviderType:     config.ProxyProviderType,
+		ProxySource:           config.ProxySource,
+		ProxyRotationStrategy: config.ProxyRotationStrategy,
+		ProxyHealthCheckURL:   config.ProxyHealthCheckURL,
+		ProxyPollInterval:     config.ProxyPollInterval,
+		RateLimitRPM:          config.RateLimitRPM,
+		RespectRetryAfter:     config.RespectRetryAfter,
+		MetricsEnabled:        config.MetricsEnabled,
 		// TODO: Add more scraper-specific configuration
 	}
 
@@ -64,23 +186,32 @@ func NewWorker(config *WorkerConfig, logger *logrus.Logger, redisClient *redis.C
 	}
 
 	return &Worker{
-		config:         config,
-		logger:         workerLogger,
-		redisClient:    redisClient,
-		scraperFactory: factory,
-		scraper:        scraperInstance,
-		metrics:        &WorkerMetrics{},
+		config:          config,
+		logger:          workerLogger,
+		redisClient:     redisClient,
+		scraperFactory:  factory,
+		scraper:         scraperInstance,
+		metrics:         &WorkerMetrics{},
+		scrapeScheduler: NewScrapeScheduler(config.DispatchInterval),
 	}, nil
 }
 
-// ProcessTask processes a single scraping task with retry logic
+// ProcessTask runs a single attempt at a scraping task. Re-delivery after a
+// failed attempt is entirely the queue layer's responsibility (see
+// Orchestrator.processNextTask's ScheduleRetry/dead-letter handling and
+// redis.RetryDispatcher) - ProcessTask used to also retry task.MaxRetries
+// times internally, which meant a queue-level retry re-ran that whole
+// internal loop again, compounding into roughly (MaxRetries+1)^2 attempts
+// instead of the MaxRetries the task actually asked for. Attempting once
+// here and letting the already-built delayed-retry ZSET own backoff and
+// re-delivery keeps the retry budget honest.
 func (w *Worker) ProcessTask(ctx context.Context, task *protocol.ScrapingTask) (*protocol.ScrapingResult, error) {
 	startTime := time.Now()
-	
+
 	w.logger.WithFields(logrus.Fields{
-		"task_id":      task.TaskID,
-		"search_term":  task.Params.SearchTerm,
-		"location":     task.Params.Location,
+		"task_id":        task.TaskID,
+		"search_term":    task.Params.SearchTerm,
+		"location":       task.Params.Location,
 		"results_wanted": task.Params.ResultsWanted,
 	}).Info("Starting task processing")
 
@@ -96,66 +227,275 @@ func (w *Worker) ProcessTask(ctx context.Context, task *protocol.ScrapingTask) (
 		return nil, fmt.Errorf("scraper params validation failed: %w", err)
 	}
 
-	var result *protocol.ScrapingResult
-	var lastErr error
-
-	// Retry logic with exponential backoff
-	for attempt := 0; attempt <= task.MaxRetries; attempt++ {
-		if attempt > 0 {
-			w.metrics.TasksRetried++
-			
-			// Calculate backoff delay
-			backoffDelay := w.calculateBackoffDelay(attempt)
-			w.logger.WithFields(logrus.Fields{
-				"attempt": attempt,
-				"delay":   backoffDelay,
-			}).Info("Retrying task after delay")
-			
-			select {
-			case <-time.After(backoffDelay):
-			case <-ctx.Done():
-				w.updateMetrics(false, time.Since(startTime))
-				return nil, ctx.Err()
+	w.stampTaskHash(task)
+
+	sinks, err := w.newOutputSinks(task)
+	if err != nil {
+		w.logger.WithError(err).Warn("Failed to initialize output sinks, continuing without file output")
+		sinks = nil
+	}
+	if sinks != nil {
+		defer func() {
+			if closeErr := sinks.Close(); closeErr != nil {
+				w.logger.WithError(closeErr).WithField("task_id", task.TaskID).Warn("Failed to finalize output sinks")
 			}
+		}()
+	}
+
+	// Stagger the start of this task against other workers hitting the
+	// same site, so a batch enqueue doesn't send every worker at it at once.
+	site := string(task.ScraperType)
+	dispatchGap, err := w.scrapeScheduler.Wait(ctx, site)
+	if err != nil {
+		w.updateMetrics(false, time.Since(startTime))
+		return nil, fmt.Errorf("scrape dispatch wait interrupted: %w", err)
+	}
+	outcome := string(protocol.TaskStatusFailed)
+	defer func() {
+		w.scrapeScheduler.RecordOutcome(site, dispatchGap, outcome)
+	}()
+
+	var updater TaskUpdater
+	if w.config.StreamUpdates {
+		updater = NewRedisTaskUpdater(w.redisClient, task.TaskID, w.logger)
+	}
+
+	// Create context with timeout for this attempt
+	attemptCtx, cancel := context.WithTimeout(ctx, w.config.TaskTimeout)
+	defer cancel()
+
+	w.fireHeartbeat()
+	if updater != nil {
+		updater.SendHeartbeat()
+	}
+
+	// Execute scraping. The attempt can legitimately run up to TaskTimeout,
+	// well past UpdateInterval, so keep the orchestrator's soft-cancel
+	// deadline pushed out for as long as it's actually in-flight rather
+	// than just at the start.
+	stopHeartbeat := w.startAttemptHeartbeat()
+	attemptStart := time.Now()
+	result, err := w.executeScrape(attemptCtx, task, updater, sinks)
+	attemptDuration := time.Since(attemptStart)
+	stopHeartbeat()
+
+	if err == nil {
+		w.dedupJobs(result)
+
+		w.logger.WithFields(logrus.Fields{
+			"duration":   attemptDuration,
+			"jobs_found": result.JobsFound,
+		}).Info("Task completed successfully")
+
+		w.updateMetrics(true, time.Since(startTime))
+		outcome = string(protocol.TaskStatusSuccess)
+		if updater != nil {
+			updater.SendTerminal(protocol.TaskStatusSuccess, nil)
 		}
+		return result, nil
+	}
+
+	w.logger.WithError(err).WithField("duration", attemptDuration).Warn("Task attempt failed")
+	w.updateMetrics(false, time.Since(startTime))
+	finalErr := fmt.Errorf("task attempt failed: %w", err)
+	if updater != nil {
+		updater.SendTerminal(protocol.TaskStatusFailed, finalErr)
+	}
+	return nil, finalErr
+}
+
+// stampTaskHash computes this task's protocol.TaskHash and stores it back
+// onto task.Params so the scraper backend can thread it through as the
+// X-Task-Hash header (see jobspy_client.doJobSpyRequest), injects it into
+// Params.CallbackURL if the submitter gave one, and records a hash ->
+// task-metadata mapping in Redis for the "worker search --hash" lookup.
+// Failures to record the Redis mapping are logged but not fatal - the task
+// itself doesn't depend on the lookup existing.
+func (w *Worker) stampTaskHash(task *protocol.ScrapingTask) {
+	ruleVersion := "none"
+	if versioner, ok := w.scraper.(scraper.RuleVersioner); ok {
+		ruleVersion = versioner.RuleVersion()
+	}
 
-		// Create context with timeout for this attempt
-		attemptCtx, cancel := context.WithTimeout(ctx, w.config.TaskTimeout)
-		
-		// Execute scraping
-		attemptStart := time.Now()
-		result, lastErr = w.scraper.ScrapeJobs(attemptCtx, task.Params)
-		attemptDuration := time.Since(attemptStart)
-		
-		cancel()
-
-		if lastErr == nil {
-			// Success
-			w.logger.WithFields(logrus.Fields{
-				"attempt":       attempt + 1,
-				"duration":      attemptDuration,
-				"jobs_found":    result.JobsFound,
-			}).Info("Task completed successfully")
-			
-			w.updateMetrics(true, time.Since(startTime))
-			return result, nil
+	hash := protocol.ComputeTaskHash(task.ScraperType, task.Params, 0, w.config.Region, ruleVersion)
+	task.Params.TaskHash = hash
+
+	if task.Params.CallbackURL != nil {
+		injected := protocol.InjectTaskHash(*task.Params.CallbackURL, hash)
+		task.Params.CallbackURL = &injected
+	}
+
+	if err := w.redisClient.SetTaskHash(hash, task, w.config.Region, ruleVersion, w.config.TaskTimeout); err != nil {
+		w.logger.WithError(err).WithField("task_id", task.TaskID).Warn("Failed to record task hash mapping")
+	}
+}
+
+// newOutputSinks builds this task's output.SinkSet from WorkerConfig, rooted
+// at a subdirectory named after the task's TaskID so concurrent tasks never
+// share output files. Returns nil, nil (not an error) when OutputDir or
+// OutputFormats is unset, so file output is simply skipped.
+func (w *Worker) newOutputSinks(task *protocol.ScrapingTask) (*output.SinkSet, error) {
+	if w.config.OutputDir == "" || len(w.config.OutputFormats) == 0 {
+		return nil, nil
+	}
+	runDir := filepath.Join(w.config.OutputDir, task.TaskID)
+	return output.NewSinkSet(w.config.OutputFormats, runDir)
+}
+
+// writeToSinks fans jobs out to sinks if non-nil, logging (not failing the
+// task on) a write error - file output is a best-effort mirror of what's
+// already published to Redis, not a prerequisite for task success.
+func (w *Worker) writeToSinks(sinks *output.SinkSet, taskID string, jobs []protocol.JobData) {
+	if sinks == nil {
+		return
+	}
+	for _, job := range jobs {
+		if err := sinks.WriteJob(job); err != nil {
+			w.logger.WithError(err).WithField("task_id", taskID).Warn("Failed to write job to output sinks")
 		}
+	}
+}
+
+// executeScrape runs a single scrape attempt, preferring the most granular
+// streaming the scraper supports: per-job (JobStreamingScraper) over
+// per-page TaskUpdate batches (StreamingScraper) over the plain
+// (non-streaming) ScrapeJobs call. When updater is nil, per-page streaming
+// isn't useful, so only JobStreamingScraper is tried before falling back to
+// plain ScrapeJobs. sinks (may be nil) receives every job as soon as its
+// containing batch/page arrives - except for the plain ScrapeJobs fallback,
+// where the whole result only exists once the call returns, so sinks see
+// every job there in one batch instead of incrementally.
+func (w *Worker) executeScrape(ctx context.Context, task *protocol.ScrapingTask, updater TaskUpdater, sinks *output.SinkSet) (*protocol.ScrapingResult, error) {
+	if jobStreaming, ok := w.scraper.(scraper.JobStreamingScraper); ok {
+		return w.executeJobStream(ctx, task, updater, jobStreaming, sinks)
+	}
 
-		// Check if error is retryable
-		if !w.isRetryableError(lastErr) {
-			w.logger.WithError(lastErr).WithField("attempt", attempt+1).Error("Non-retryable error, stopping retries")
-			break
+	if updater == nil {
+		result, err := w.scraper.ScrapeJobs(ctx, task.Params)
+		if result != nil {
+			w.writeToSinks(sinks, task.TaskID, result.JobsData)
 		}
+		return result, err
+	}
 
-		w.logger.WithError(lastErr).WithFields(logrus.Fields{
-			"attempt":  attempt + 1,
-			"duration": attemptDuration,
-		}).Warn("Task attempt failed, will retry")
+	streaming, ok := w.scraper.(scraper.StreamingScraper)
+	if !ok {
+		streaming = scraper.DefaultStreamingAdapter(w.scraper)
 	}
 
-	// All attempts failed
-	w.updateMetrics(false, time.Since(startTime))
-	return nil, fmt.Errorf("task failed after %d attempts: %w", task.MaxRetries+1, lastErr)
+	updates := make(chan protocol.TaskUpdate, 16)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for update := range updates {
+			updater.SendProgress(update.PagesScraped, update.JobsBatch)
+			w.writeToSinks(sinks, task.TaskID, update.JobsBatch)
+		}
+	}()
+
+	result, err := streaming.ScrapeJobsStreaming(ctx, task.Params, updates)
+	close(updates)
+	<-done
+
+	return result, err
+}
+
+// executeJobStream runs a scrape through JobStreamingScraper, XADDing each
+// job onto the task's Redis stream (protocol.GetJobsStreamKey) as soon as
+// it's decoded, so a downstream consumer can start working on it before the
+// scrape finishes. It still assembles a ScrapingResult from everything
+// streamed, so callers don't need to know this path was used.
+func (w *Worker) executeJobStream(ctx context.Context, task *protocol.ScrapingTask, updater TaskUpdater, streaming scraper.JobStreamingScraper, sinks *output.SinkSet) (*protocol.ScrapingResult, error) {
+	startTime := time.Now()
+	streamKey := protocol.GetJobsStreamKey(task.TaskID)
+
+	jobs := make(chan protocol.JobData, 16)
+	done := make(chan struct{})
+	var collected []protocol.JobData
+	go func() {
+		defer close(done)
+		for job := range jobs {
+			collected = append(collected, job)
+			if _, err := w.redisClient.PushTaskStream(streamKey, job); err != nil {
+				w.logger.WithError(err).WithField("task_id", task.TaskID).Warn("Failed to stream job to Redis")
+			}
+			w.writeToSinks(sinks, task.TaskID, []protocol.JobData{job})
+			if updater != nil {
+				updater.SendProgress(0, []protocol.JobData{job})
+			}
+		}
+	}()
+
+	// streaming.ScrapeJobsStream closes jobs itself once the scrape ends.
+	err := streaming.ScrapeJobsStream(ctx, task.Params, jobs)
+	<-done
+
+	result := protocol.NewScrapingResult(task.TaskID, task.ScraperType)
+	result.JobsFound = len(collected)
+	result.JobsData = collected
+	result.ExecutionTime = time.Since(startTime).Seconds()
+	result.CompletedAt = time.Now().UTC().Format(time.RFC3339)
+	if err != nil {
+		result.Status = protocol.TaskStatusFailed
+		errMsg := err.Error()
+		result.Error = &errMsg
+	} else {
+		result.Status = protocol.TaskStatusSuccess
+	}
+
+	return result, err
+}
+
+// dedupJobs drops JobsData entries whose JobHash was already published for
+// this scraper type within jobHashTTL (see redis.FilterNewJobHashes),
+// then records the survivors so a later task recognizes them too (see
+// redis.RecordJobHashes) - run before a successful ScrapingResult is
+// handed back to the orchestrator for publishing. Jobs without a JobHash
+// can't be deduplicated and are always kept. Best-effort: a Redis error
+// is logged, not fatal - returning possibly-duplicate jobs beats losing
+// the result entirely.
+func (w *Worker) dedupJobs(result *protocol.ScrapingResult) {
+	if result == nil || len(result.JobsData) == 0 {
+		return
+	}
+
+	hashes := make([]string, 0, len(result.JobsData))
+	for _, job := range result.JobsData {
+		if job.JobHash != nil {
+			hashes = append(hashes, *job.JobHash)
+		}
+	}
+	if len(hashes) == 0 {
+		return
+	}
+
+	fresh, err := w.redisClient.FilterNewJobHashes(result.ScraperType, hashes)
+	if err != nil {
+		w.logger.WithError(err).WithField("task_id", result.TaskID).Warn("Failed to filter duplicate job hashes, publishing unfiltered")
+		return
+	}
+
+	freshSet := make(map[string]struct{}, len(fresh))
+	for _, h := range fresh {
+		freshSet[h] = struct{}{}
+	}
+
+	deduped := result.JobsData[:0]
+	for _, job := range result.JobsData {
+		if job.JobHash == nil {
+			deduped = append(deduped, job)
+			continue
+		}
+		if _, ok := freshSet[*job.JobHash]; ok {
+			deduped = append(deduped, job)
+		}
+	}
+	result.JobsData = deduped
+	result.JobsFound = len(deduped)
+
+	if err := w.redisClient.RecordJobHashes(result.ScraperType, fresh, jobHashTTL); err != nil {
+		w.logger.WithError(err).WithField("task_id", result.TaskID).Warn("Failed to record job hashes for future dedup")
+	}
 }
 
 // GetMetrics returns current worker metrics
@@ -166,28 +506,28 @@ func (w *Worker) GetMetrics() *WorkerMetrics {
 // GetHealthStatus returns worker health status
 func (w *Worker) GetHealthStatus() *protocol.HealthStatus {
 	scraperHealth := w.scraper.GetHealthStatus()
-	
+
 	// Update with worker-specific metrics
 	scraperHealth.WorkerID = w.config.WorkerID
 	scraperHealth.ActiveTasks = 0 // TODO: Track active tasks
 	scraperHealth.CompletedTasksLastHour = int(w.metrics.TasksSuccessful)
-	
+
 	if w.metrics.TasksProcessed > 0 {
 		scraperHealth.ErrorRateLastHour = float64(w.metrics.TasksFailed) / float64(w.metrics.TasksProcessed)
 	}
-	
+
 	return scraperHealth
 }
 
 // Close cleans up worker resources
 func (w *Worker) Close() error {
 	w.logger.Info("Shutting down worker")
-	
+
 	if err := w.scraper.Close(); err != nil {
 		w.logger.WithError(err).Error("Error closing scraper")
 		return err
 	}
-	
+
 	return nil
 }
 
@@ -196,101 +536,14 @@ func (w *Worker) updateMetrics(success bool, duration time.Duration) {
 	w.metrics.TasksProcessed++
 	w.metrics.LastTaskTime = time.Now()
 	w.metrics.TotalProcessTime += duration
-	
+
 	if w.metrics.TasksProcessed > 0 {
 		w.metrics.AverageTaskTime = time.Duration(int64(w.metrics.TotalProcessTime) / w.metrics.TasksProcessed)
 	}
-	
+
 	if success {
 		w.metrics.TasksSuccessful++
 	} else {
 		w.metrics.TasksFailed++
 	}
 }
-
-// calculateBackoffDelay calculates exponential backoff delay
-func (w *Worker) calculateBackoffDelay(attempt int) time.Duration {
-	baseDelay := w.config.RetryDelay
-	if baseDelay == 0 {
-		baseDelay = 5 * time.Second
-	}
-	
-	// Exponential backoff: baseDelay * 2^(attempt-1)
-	multiplier := 1 << uint(attempt-1) // 2^(attempt-1)
-	if multiplier > 16 {
-		multiplier = 16 // Cap at 16x base delay
-	}
-	
-	delay := time.Duration(multiplier) * baseDelay
-	
-	// Add jitter (±25%)
-	jitter := time.Duration(float64(delay) * 0.25 * (2*randFloat() - 1))
-	return delay + jitter
-}
-
-// isRetryableError determines if an error should trigger a retry
-func (w *Worker) isRetryableError(err error) bool {
-	if err == nil {
-		return false
-	}
-	
-	// Check for specific error types
-	if scrapingErr, ok := err.(scraper.ScrapingError); ok {
-		return scrapingErr.Retryable
-	}
-	
-	// Context errors are not retryable
-	if err == context.DeadlineExceeded || err == context.Canceled {
-		return false
-	}
-	
-	// Validation errors are not retryable
-	if _, ok := err.(scraper.ValidationError); ok {
-		return false
-	}
-	
-	// Default: retry network and temporary errors
-	errStr := err.Error()
-	retryablePatterns := []string{
-		"connection refused",
-		"timeout",
-		"temporary failure",
-		"service unavailable",
-		"internal server error",
-		"bad gateway",
-		"gateway timeout",
-	}
-	
-	for _, pattern := range retryablePatterns {
-		if contains(errStr, pattern) {
-			return true
-		}
-	}
-	
-	return false
-}
-
-// Helper functions
-func randFloat() float64 {
-	// Simple pseudo-random float [0,1)
-	// In production, use crypto/rand for better randomness
-	return float64(time.Now().UnixNano()%1000) / 1000.0
-}
-
-func contains(haystack, needle string) bool {
-	return len(haystack) >= len(needle) && 
-		   (haystack == needle || 
-		    (len(haystack) > len(needle) && 
-		     (haystack[:len(needle)] == needle || 
-		      haystack[len(haystack)-len(needle):] == needle ||
-		      indexOf(haystack, needle) >= 0)))
-}
-
-func indexOf(haystack, needle string) int {
-	for i := 0; i <= len(haystack)-len(needle); i++ {
-		if haystack[i:i+len(needle)] == needle {
-			return i
-		}
-	}
-	return -1
-}
\ No newline at end of file
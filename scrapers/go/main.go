@@ -11,10 +11,32 @@ import (
 	"github.com/jobspy/scrapers/internal/config"
 	"github.com/jobspy/scrapers/internal/redis"
 	"github.com/jobspy/scrapers/internal/scraper"
+	// Blank-imported so its init() registers the "jobspy" backend with the
+	// scraper package's registry (see scraper.Register).
+	_ "github.com/jobspy/scrapers/internal/scrapers/jobspy_client"
 	"github.com/jobspy/scrapers/internal/worker"
 )
 
+// Scrape rule hot-reload (see internal/scraper/rules) is driven by fsnotify
+// watching ScrapeRulesDir, not SIGHUP: the orchestrator owns each worker's
+// scraper instance and main has no handle to reach into it on a signal, so
+// fsnotify - which the scraper already wires up on its own, like
+// config.Watcher does for the config file - is the only half of "SIGHUP or
+// fsnotify" actually wired here.
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "--dry-run-rules" {
+		os.Exit(runDryRunRules(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "search" {
+		os.Exit(runSearchHash(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "doctor" {
+		os.Exit(runDoctor(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "schedule-recurring" {
+		os.Exit(runScheduleRecurring(os.Args[2:]))
+	}
+
 	// Setup logger
 	logger := logrus.New()
 	logger.SetLevel(logrus.InfoLevel)
@@ -42,9 +64,11 @@ func main() {
 
 	// Create Redis client
 	redisConfig := &redis.Config{
-		URL:      cfg.RedisURL,
-		Password: cfg.RedisPassword,
-		DB:       cfg.RedisDB,
+		URL:          cfg.RedisURL,
+		Password:     cfg.RedisPassword,
+		DB:           cfg.RedisDB,
+		QueueBackend: redis.QueueBackend(cfg.QueueBackend),
+		PipePeriod:   cfg.PipePeriod,
 	}
 
 	redisClient, err := redis.NewClient(redisConfig, logger)
@@ -52,14 +76,16 @@ func main() {
 		logger.WithError(err).Fatal("Failed to create Redis client")
 	}
 
-	// Create scraper factory
-	scraperFactory := scraper.NewFactory(logger)
+	// Create scraper factory. redisClient doubles as the ratelimit.Persister
+	// backing the factory's shared AdaptiveLimiter (see
+	// redis.Client.SetLimiterState/GetLimiterState).
+	scraperFactory := scraper.NewFactory(logger, redisClient, cfg.MetricsEnabled)
 
 	// Create orchestrator
 	orchestrator := worker.NewOrchestrator(cfg, logger, redisClient, scraperFactory)
 
 	// Setup graceful shutdown
-	ctx, cancel := context.WithCancel(context.Background())
+	_, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	// Handle shutdown signals
@@ -84,4 +110,4 @@ func main() {
 	}
 
 	logger.Info("Scraper worker shutdown complete")
-}
\ No newline at end of file
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/jobspy/scrapers/internal/config"
+	"github.com/jobspy/scrapers/internal/protocol"
+	"github.com/jobspy/scrapers/internal/redis"
+)
+
+// runScheduleRecurring implements `schedule-recurring`: a standing process
+// that re-submits one ScrapingTask on a fixed interval via
+// redis.Client.ScheduleRecurring, which staggers the task's phase within
+// that interval instead of every recurring task firing at the boundary at
+// once. Unlike the cron-based PeriodicJob system (see
+// internal/redis/periodic.go), this has no REST surface or execution
+// history - it's meant for a single standing search an operator wants
+// running continuously, started and supervised the same way as the worker
+// process itself (e.g. its own systemd unit/container).
+func runScheduleRecurring(args []string) int {
+	fs := flag.NewFlagSet("schedule-recurring", flag.ContinueOnError)
+	taskID := fs.String("task-id", "", "stable task ID (required - determines the recurring phase offset)")
+	scraperType := fs.String("scraper-type", "", "scraper type, e.g. indeed (required)")
+	searchTerm := fs.String("search-term", "", "search term (required)")
+	location := fs.String("location", "", "location (required)")
+	resultsWanted := fs.Int("results-wanted", 50, "results wanted per run")
+	timeout := fs.Int("timeout", 300, "per-run task timeout in seconds")
+	interval := fs.Duration("interval", time.Hour, "how often to re-submit the task")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *taskID == "" || *scraperType == "" || *searchTerm == "" || *location == "" {
+		fmt.Fprintln(os.Stderr, "usage: schedule-recurring --task-id <id> --scraper-type <type> --search-term <term> --location <loc> [--results-wanted N] [--timeout S] [--interval D]")
+		return 2
+	}
+
+	logger := logrus.New()
+	logger.SetFormatter(&logrus.JSONFormatter{})
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load configuration: %v\n", err)
+		return 1
+	}
+
+	redisClient, err := redis.NewClient(&redis.Config{
+		URL:      cfg.RedisURL,
+		Password: cfg.RedisPassword,
+		DB:       cfg.RedisDB,
+	}, logger)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to connect to Redis: %v\n", err)
+		return 1
+	}
+	defer redisClient.Close()
+
+	task := &protocol.ScrapingTask{
+		TaskID:      *taskID,
+		ScraperType: protocol.ScraperType(*scraperType),
+		Params: protocol.ScrapingTaskParams{
+			SearchTerm:    *searchTerm,
+			Location:      *location,
+			ResultsWanted: *resultsWanted,
+		},
+		Timeout:    *timeout,
+		MaxRetries: cfg.MaxRetries,
+	}
+
+	submit := func() {
+		if err := redisClient.ScheduleRecurring(task, *interval); err != nil {
+			logger.WithError(err).Error("Failed to schedule recurring task")
+			return
+		}
+		logger.WithField("task_id", task.TaskID).Info("Recurring task scheduled")
+	}
+
+	submit()
+
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+
+	for {
+		select {
+		case <-ticker.C:
+			submit()
+		case <-sigCh:
+			logger.Info("Recurring scheduler shutting down")
+			return 0
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/jobspy/scrapers/internal/config"
+	"github.com/jobspy/scrapers/internal/redis"
+)
+
+// runSearchHash implements `search --hash <hash>`: it resolves a
+// protocol.TaskHash back to the task metadata a worker recorded for it via
+// redis.Client.SetTaskHash, the same "search FFUFHASH" capability ffuf
+// offers for correlating a blind callback back to the request that caused
+// it. Connects to Redis using the same configuration main() would load, but
+// doesn't start the worker loop.
+func runSearchHash(args []string) int {
+	if len(args) != 2 || args[0] != "--hash" {
+		fmt.Fprintln(os.Stderr, "usage: search --hash <hash>")
+		return 2
+	}
+	hash := args[1]
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load configuration: %v\n", err)
+		return 1
+	}
+
+	redisClient, err := redis.NewClient(&redis.Config{
+		URL:      cfg.RedisURL,
+		Password: cfg.RedisPassword,
+		DB:       cfg.RedisDB,
+	}, logger)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to connect to Redis: %v\n", err)
+		return 1
+	}
+	defer redisClient.Close()
+
+	record, found, err := redisClient.GetTaskHash(hash)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to look up hash %s: %v\n", hash, err)
+		return 1
+	}
+	if !found {
+		fmt.Fprintf(os.Stderr, "no task found for hash %s (expired or never recorded)\n", hash)
+		return 1
+	}
+
+	out, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to format result: %v\n", err)
+		return 1
+	}
+	fmt.Println(string(out))
+	return 0
+}